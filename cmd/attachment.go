@@ -30,8 +30,7 @@ var attachmentCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
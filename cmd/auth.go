@@ -3,9 +3,17 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"os/exec"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/blockful/clickup-cli/internal/api"
 	"github.com/blockful/clickup-cli/internal/config"
@@ -20,14 +28,41 @@ var authCmd = &cobra.Command{
 
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
-	Short: "Authenticate with ClickUp API token",
+	Short: "Authenticate with a ClickUp API token or OAuth",
+	Long:  "Authenticate with a personal API token, or with --oauth through a ClickUp OAuth app: the CLI opens the authorization page, receives the code on a localhost redirect and exchanges it for an access token.",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		useOAuth, _ := cmd.Flags().GetBool("oauth")
 		token, _ := cmd.Flags().GetString("token")
 
-		if token == "" {
+		if useOAuth {
+			clientID, _ := cmd.Flags().GetString("client-id")
+			clientSecret, _ := cmd.Flags().GetString("client-secret")
+			if clientID == "" || clientSecret == "" {
+				output.PrintError("VALIDATION_ERROR", "--client-id and --client-secret are required with --oauth")
+				return &exitError{code: 1}
+			}
+			port, _ := cmd.Flags().GetInt("redirect-port")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			noBrowser, _ := cmd.Flags().GetBool("no-browser")
+
+			code, err := authorizeOAuth(ctx, clientID, port, timeout, !noBrowser)
+			if err != nil {
+				output.PrintError("OAUTH_ERROR", err.Error())
+				return &exitError{code: 1}
+			}
+			resp, err := newClient("").GetAccessToken(ctx, &api.AccessTokenRequest{
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+				Code:         code,
+			})
+			if err != nil {
+				return handleError(err)
+			}
+			token = resp.AccessToken
+		} else if token == "" {
 			fmt.Print("Enter your ClickUp API token: ")
-			reader := bufio.NewReader(os.Stdin)
-			input, err := reader.ReadString('\n')
+			input, err := stdinReader.ReadString('\n')
 			if err != nil {
 				output.PrintError("INPUT_ERROR", "failed to read token")
 				return &exitError{code: 1}
@@ -41,28 +76,201 @@ var authLoginCmd = &cobra.Command{
 		}
 
 		// Validate token by calling /v2/user
-		client := api.NewClient(token)
-		ctx := context.Background()
+		client := newClient(token)
+		client.TokenType = ""
+		if useOAuth {
+			client.TokenType = api.TokenTypeBearer
+		}
 		user, err := client.GetUser(ctx)
 		if err != nil {
 			return handleError(err)
 		}
 
-		// Save token
-		if err := config.SetToken(token); err != nil {
+		// Save token in the credential store
+		store, err := config.Credentials()
+		if err != nil {
+			output.PrintError("CONFIG_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		save, authType := config.SetToken, "token"
+		if useOAuth {
+			save, authType = config.SetOAuthToken, config.AuthTypeOAuth
+		}
+		if err := save(token); err != nil {
 			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save token: %v", err))
 			return &exitError{code: 1}
 		}
 
-		output.JSON(map[string]interface{}{
-			"message": "authenticated successfully",
-			"user":    user.User,
-			"config":  config.ConfigFilePath(),
+		return output.Print(map[string]interface{}{
+			"message":          "authenticated successfully",
+			"user":             user.User,
+			"config":           config.ConfigFilePath(),
+			"credential_store": store.Name(),
+			"auth_type":        authType,
 		})
-		return nil
 	},
 }
 
+// authorizeOAuth sends the user to the ClickUp authorization page and waits
+// for the redirect to a listener on 127.0.0.1, returning the authorization
+// code. port 0 picks a free port.
+func authorizeOAuth(ctx context.Context, clientID string, port int, timeout time.Duration, browser bool) (string, error) {
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return "", fmt.Errorf("cannot listen for the OAuth redirect: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/callback", ln.Addr().(*net.TCPAddr).Port)
+
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		ln.Close()
+		return "", err
+	}
+	state := hex.EncodeToString(stateBytes)
+
+	authURL := api.OAuthAuthorizeURL(clientID, redirectURI, state)
+	fmt.Fprintf(os.Stderr, "Open this URL to authorize clickup-cli:\n\n  %s\n\nWaiting for the redirect to %s\n", authURL, redirectURI)
+	if browser {
+		_ = openBrowser(authURL)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return waitForOAuthCode(ctx, ln, state)
+}
+
+// waitForOAuthCode serves the OAuth redirect on ln until it receives an
+// authorization code or an error, or ctx is done. A state parameter that
+// does not match is rejected.
+func waitForOAuthCode(ctx context.Context, ln net.Listener, state string) (string, error) {
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	send := func(r result) {
+		select {
+		case results <- r:
+		default:
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("error") != "":
+			http.Error(w, "Authorization failed. You can close this window.", http.StatusBadRequest)
+			send(result{err: fmt.Errorf("authorization denied: %s", q.Get("error"))})
+		case q.Get("state") == "":
+			// Without the state, the redirect could have been forged by another site
+			http.Error(w, "Missing state parameter.", http.StatusBadRequest)
+			send(result{err: errors.New("OAuth state missing from the redirect")})
+		case q.Get("state") != state:
+			http.Error(w, "Invalid state parameter.", http.StatusBadRequest)
+			send(result{err: errors.New("OAuth state mismatch")})
+		case q.Get("code") == "":
+			http.Error(w, "Missing authorization code.", http.StatusBadRequest)
+		default:
+			fmt.Fprintln(w, "clickup-cli is authorized. You can close this window.")
+			send(result{code: q.Get("code")})
+		}
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	defer func() { _ = srv.Close() }()
+
+	select {
+	case r := <-results:
+		return r.code, r.err
+	case <-ctx.Done():
+		return "", errors.New("timed out waiting for the OAuth redirect")
+	}
+}
+
+// openBrowser opens url in the user's browser. It can be replaced in tests.
+var openBrowser = func(url string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	return c.Start()
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the saved API token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := config.Credentials()
+		if err != nil {
+			output.PrintError("CONFIG_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		if err := config.DeleteToken(); err != nil {
+			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to remove token: %v", err))
+			return &exitError{code: 1}
+		}
+		return output.Print(map[string]interface{}{
+			"message":          "logged out",
+			"profile":          config.ActiveProfile(),
+			"credential_store": store.Name(),
+		})
+	},
+}
+
+// promptPassphrase reads the credentials file passphrase from the terminal
+// with echo disabled, asking twice when the file is being created.
+func promptPassphrase(confirm bool) (string, error) {
+	pass, err := readSecret("Credentials passphrase: ")
+	if err != nil || !confirm {
+		return pass, err
+	}
+	again, err := readSecret("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if again != pass {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return pass, nil
+}
+
+// readSecret prompts on stderr and reads a line from stdin, turning off
+// terminal echo while it does.
+func readSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		if err := stty("-echo"); err == nil {
+			defer func() {
+				_ = stty("echo")
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+	input, err := stdinReader.ReadString('\n')
+	if err != nil && input == "" {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimSpace(input), nil
+}
+
+func stty(arg string) error {
+	c := exec.Command("stty", arg)
+	c.Stdin = os.Stdin
+	return c.Run()
+}
+
+// stdinReader is shared by prompts so buffered input is not lost between them.
+var stdinReader = bufio.NewReader(os.Stdin)
+
 var authWhoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Show current authenticated user",
@@ -73,14 +281,20 @@ var authWhoamiCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(user.User)
-		return nil
+		return output.Print(user.User)
 	},
 }
 
 func init() {
 	authLoginCmd.Flags().String("token", "", "API token (if not provided, will prompt)")
+	authLoginCmd.Flags().Bool("oauth", false, "Log in through a ClickUp OAuth app instead of a personal token")
+	authLoginCmd.Flags().String("client-id", "", "OAuth app client ID (with --oauth)")
+	authLoginCmd.Flags().String("client-secret", "", "OAuth app client secret (with --oauth)")
+	authLoginCmd.Flags().Int("redirect-port", 0, "Port for the localhost OAuth redirect (0 = any free port)")
+	authLoginCmd.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the OAuth redirect")
+	authLoginCmd.Flags().Bool("no-browser", false, "Print the authorization URL without opening a browser")
 	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authWhoamiCmd)
 	rootCmd.AddCommand(authCmd)
 }
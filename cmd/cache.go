@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/cache"
+	"github.com/blockful/clickup-cli/internal/config"
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local cache of spaces, folders, lists and members",
+}
+
+var cacheRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refetch the workspace hierarchy and members into the cache",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wsID := getWorkspaceID(cmd)
+		client := getClient()
+		store, err := openCache(wsID, client)
+		if err != nil {
+			return handleError(err)
+		}
+		// With the cache emptied, every listing below is fetched and stored.
+		if err := store.Clear(); err != nil {
+			return handleError(err)
+		}
+		ctx := context.Background()
+
+		if _, err := client.ListWorkspaces(ctx); err != nil {
+			return handleError(err)
+		}
+		spaces, err := client.ListSpaces(ctx, wsID)
+		if err != nil {
+			return handleError(err)
+		}
+		folders, lists := 0, 0
+		for _, s := range spaces.Spaces {
+			resp, err := client.ListFolders(ctx, s.ID)
+			if err != nil {
+				return handleError(err)
+			}
+			for _, f := range resp.Folders {
+				folders++
+				if f.Lists == nil {
+					folderLists, err := client.ListLists(ctx, f.ID)
+					if err != nil {
+						return handleError(err)
+					}
+					f.Lists = folderLists.Lists
+				}
+				lists += len(f.Lists)
+			}
+			folderless, err := client.ListFolderlessLists(ctx, s.ID)
+			if err != nil {
+				return handleError(err)
+			}
+			lists += len(folderless.Lists)
+		}
+		return output.Print(map[string]interface{}{
+			"workspace": wsID,
+			"path":      store.Path,
+			"spaces":    len(spaces.Spaces),
+			"folders":   folders,
+			"lists":     lists,
+		})
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the cache of the workspace, or of every workspace with --all",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := cache.Dir()
+		if err != nil {
+			return handleError(err)
+		}
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			if err := cache.ClearAll(dir); err != nil {
+				return handleError(err)
+			}
+			return output.Print(map[string]string{"message": "cache cleared", "path": dir})
+		}
+		if err := cache.ClearWorkspace(dir, getWorkspaceID(cmd)); err != nil {
+			return handleError(err)
+		}
+		return output.Print(map[string]string{"message": "cache cleared", "path": dir})
+	},
+}
+
+// noCache is the global --no-cache flag.
+var noCache bool
+
+// openCache returns the cache store of workspace wsID for client's API base
+// URL and token.
+func openCache(wsID string, client api.ClientInterface) (*cache.Store, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return cache.Open(dir, cacheKey(wsID, client)), nil
+}
+
+// cacheKey names the cache file of workspace wsID after client's API base
+// URL and token and the active profile, or the configured base URL and
+// token when client is not an *api.Client.
+func cacheKey(wsID string, client api.ClientInterface) string {
+	if c, ok := client.(*cache.Client); ok {
+		client = c.ClientInterface
+	}
+	var baseURL, token string
+	if c, ok := client.(*api.Client); ok {
+		baseURL, token = c.BaseURL, c.Token
+	} else {
+		baseURL, token = config.GetBaseURL(), config.GetToken()
+	}
+	if baseURL == "" {
+		baseURL = api.DefaultBaseURL
+	}
+	return cache.Key(wsID, strings.TrimRight(baseURL, "/"), config.ActiveProfile(), token)
+}
+
+// withCache answers hierarchy and member listings made through client from
+// the workspace's cache, unless no workspace is configured. --no-cache
+// fetches them anyway, refreshing the cache.
+func withCache(client api.ClientInterface) api.ClientInterface {
+	wsID := config.GetWorkspace()
+	if wsID == "" {
+		return client
+	}
+	store, err := openCache(wsID, client)
+	if err != nil {
+		return client
+	}
+	c := cache.NewClient(client, store)
+	if ttl := config.GetCacheTTL(); ttl > 0 {
+		c.TTL, c.MembersTTL = ttl, ttl
+	}
+	c.Refresh = noCache
+	return c
+}
+
+func init() {
+	cacheClearCmd.Flags().Bool("all", false, "Delete the cache of every workspace")
+
+	cacheCmd.AddCommand(cacheRefreshCmd, cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
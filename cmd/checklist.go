@@ -36,8 +36,7 @@ var checklistCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -67,8 +66,7 @@ var checklistUpdateCmd = &cobra.Command{
 		if err := client.EditChecklist(ctx, id, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -87,8 +85,7 @@ var checklistDeleteCmd = &cobra.Command{
 		if err := client.DeleteChecklist(ctx, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -100,7 +97,7 @@ var checklistItemCreateCmd = &cobra.Command{
 		ctx := context.Background()
 		checklistID, _ := cmd.Flags().GetString("checklist")
 		name, _ := cmd.Flags().GetString("name")
-		assignee, _ := cmd.Flags().GetInt("assignee")
+		assignee := getUserID(cmd, "assignee")
 
 		if checklistID == "" {
 			output.PrintError("VALIDATION_ERROR", "--checklist is required")
@@ -116,8 +113,7 @@ var checklistItemCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -157,8 +153,7 @@ var checklistItemUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -179,8 +174,7 @@ var checklistItemDeleteCmd = &cobra.Command{
 		if err := client.DeleteChecklistItem(ctx, checklistID, itemID); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -203,13 +197,13 @@ func init() {
 
 	checklistItemCreateCmd.Flags().String("checklist", "", "Checklist ID (required)")
 	checklistItemCreateCmd.Flags().String("name", "", "Item name")
-	checklistItemCreateCmd.Flags().Int("assignee", 0, "Assignee user ID")
+	checklistItemCreateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me)")
 
 	checklistItemUpdateCmd.Flags().String("checklist", "", "Checklist ID (required)")
 	checklistItemUpdateCmd.Flags().String("id", "", "Checklist item ID (required)")
 	checklistItemUpdateCmd.Flags().String("name", "", "New name")
 	checklistItemUpdateCmd.Flags().Bool("resolved", false, "Resolved status")
-	checklistItemUpdateCmd.Flags().String("assignee", "", "Assignee user ID or null")
+	checklistItemUpdateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me), or null")
 	checklistItemUpdateCmd.Flags().String("parent", "", "Parent checklist item ID or null")
 
 	checklistItemDeleteCmd.Flags().String("checklist", "", "Checklist ID (required)")
@@ -32,23 +32,20 @@ var commentListCmd = &cobra.Command{
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		if listID != "" {
 			resp, err := client.ListListComments(ctx, listID, startID)
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		resp, err := client.ListComments(ctx, taskID, startID, getTaskScopedOpts(cmd))
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -72,8 +69,7 @@ var commentCreateCmd = &cobra.Command{
 		}
 		req := &api.CreateCommentRequest{CommentText: text}
 		if cmd.Flags().Changed("assignee") {
-			v, _ := cmd.Flags().GetInt("assignee")
-			req.Assignee = api.IntPtr(v)
+			req.Assignee = api.IntPtr(getUserID(cmd, "assignee"))
 		}
 		if cmd.Flags().Changed("group-assignee") {
 			v, _ := cmd.Flags().GetInt("group-assignee")
@@ -86,23 +82,20 @@ var commentCreateCmd = &cobra.Command{
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		if listID != "" {
 			resp, err := client.CreateListComment(ctx, listID, req)
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		resp, err := client.CreateComment(ctx, taskID, req, getTaskScopedOpts(cmd))
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -124,8 +117,7 @@ var commentUpdateCmd = &cobra.Command{
 		}
 		req := &api.UpdateCommentRequest{CommentText: text}
 		if cmd.Flags().Changed("assignee") {
-			v, _ := cmd.Flags().GetInt("assignee")
-			req.Assignee = api.IntPtr(v)
+			req.Assignee = api.IntPtr(getUserID(cmd, "assignee"))
 		}
 		if cmd.Flags().Changed("group-assignee") {
 			v, _ := cmd.Flags().GetInt("group-assignee")
@@ -138,8 +130,7 @@ var commentUpdateCmd = &cobra.Command{
 		if err := client.UpdateComment(ctx, id, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"message": "comment updated", "id": id})
-		return nil
+		return output.Print(map[string]string{"message": "comment updated", "id": id})
 	},
 }
 
@@ -157,8 +148,7 @@ var commentDeleteCmd = &cobra.Command{
 		if err := client.DeleteComment(ctx, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"message": "comment deleted", "id": id})
-		return nil
+		return output.Print(map[string]string{"message": "comment deleted", "id": id})
 	},
 }
 
@@ -182,8 +172,7 @@ var commentReplyListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -205,38 +194,36 @@ var commentReplyCreateCmd = &cobra.Command{
 		}
 		req := &api.CreateCommentRequest{CommentText: text}
 		if cmd.Flags().Changed("assignee") {
-			v, _ := cmd.Flags().GetInt("assignee")
-			req.Assignee = api.IntPtr(v)
+			req.Assignee = api.IntPtr(getUserID(cmd, "assignee"))
 		}
 		req.NotifyAll, _ = cmd.Flags().GetBool("notify-all")
 		resp, err := client.CreateThreadedComment(ctx, commentID, req)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
 func init() {
 	commentListCmd.Flags().String("task", "", "Task ID")
-	commentListCmd.Flags().String("list", "", "List ID")
+	commentListCmd.Flags().String("list", "", "List ID or name")
 	commentListCmd.Flags().String("view-id", "", "View ID (chat view comments)")
 	commentListCmd.Flags().String("start-id", "", "Start comment ID for pagination")
 	addTaskScopedFlags(commentListCmd)
 
 	commentCreateCmd.Flags().String("task", "", "Task ID")
-	commentCreateCmd.Flags().String("list", "", "List ID")
+	commentCreateCmd.Flags().String("list", "", "List ID or name")
 	commentCreateCmd.Flags().String("view-id", "", "View ID (chat view comment)")
 	commentCreateCmd.Flags().String("text", "", "Comment text")
-	commentCreateCmd.Flags().Int("assignee", 0, "Assignee user ID")
+	commentCreateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me)")
 	commentCreateCmd.Flags().Int("group-assignee", 0, "Group assignee ID")
 	commentCreateCmd.Flags().Bool("notify-all", false, "Notify all")
 	addTaskScopedFlags(commentCreateCmd)
 
 	commentUpdateCmd.Flags().String("id", "", "Comment ID")
 	commentUpdateCmd.Flags().String("text", "", "Comment text")
-	commentUpdateCmd.Flags().Int("assignee", 0, "Assignee user ID")
+	commentUpdateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me)")
 	commentUpdateCmd.Flags().Int("group-assignee", 0, "Group assignee ID")
 	commentUpdateCmd.Flags().Bool("resolved", false, "Mark as resolved")
 
@@ -245,7 +232,7 @@ func init() {
 	commentReplyListCmd.Flags().String("comment-id", "", "Comment ID")
 	commentReplyCreateCmd.Flags().String("comment-id", "", "Comment ID")
 	commentReplyCreateCmd.Flags().String("text", "", "Comment text")
-	commentReplyCreateCmd.Flags().Int("assignee", 0, "Assignee user ID")
+	commentReplyCreateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me)")
 	commentReplyCreateCmd.Flags().Bool("notify-all", false, "Notify all")
 
 	commentReplyCmd.AddCommand(commentReplyListCmd)
@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/config"
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/blockful/clickup-cli/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit CLI configuration",
+}
+
+// configKey describes a setting that "config get|set|unset|list" accepts.
+type configKey struct {
+	name        string
+	description string
+	validate    func(value string) error
+}
+
+var configKeys = []configKey{
+	{name: config.KeyToken, description: "API token, saved in the credential store", validate: validateNonEmpty},
+	{name: config.KeyWorkspace, description: "Default workspace ID", validate: validateID},
+	{name: config.KeyDefaultList, description: "List used when --list is omitted (ID or name)", validate: validateID},
+	{name: config.KeyDefaultSpace, description: "Space used when --space is omitted (ID or name)", validate: validateID},
+	{name: config.KeyFormat, description: "Default output format", validate: validateFormat},
+	{name: config.KeyBaseURL, description: "API base URL", validate: validateURL},
+	{name: config.KeyMaxRetries, description: "Retries for 429 and 5xx responses", validate: validateNonNegativeInt},
+	{name: config.KeyRetryBaseWait, description: "Base wait between retries, doubled per attempt (e.g. 1s)", validate: validateDuration},
+	{name: config.KeyTimeout, description: "HTTP request timeout (e.g. 30s)", validate: validateDuration},
+	{name: config.KeyUserAgent, description: "User-Agent header sent with every request", validate: validateNonEmpty},
+	{name: config.KeyCacheTTL, description: "How long cached spaces, folders, lists and members stay fresh (e.g. 15m)", validate: validateDuration},
+	{name: config.KeyCredentialStore, description: "Where tokens are saved: auto, keyring or file", validate: validateOneOf("auto", "keyring", "file")},
+}
+
+// findConfigKey returns the known setting named name, accepting dashes for
+// underscores (default-list).
+func findConfigKey(name string) (configKey, bool) {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	for _, k := range configKeys {
+		if k.name == name {
+			return k, true
+		}
+	}
+	return configKey{}, false
+}
+
+func configKeyNames() string {
+	names := make([]string, len(configKeys))
+	for i, k := range configKeys {
+		names[i] = k.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// lookupConfigKey resolves a config key argument, printing a validation
+// error for unknown keys.
+func lookupConfigKey(name string) (configKey, error) {
+	k, ok := findConfigKey(name)
+	if !ok {
+		output.PrintError("VALIDATION_ERROR", fmt.Sprintf("unknown config key %q (valid: %s)", name, configKeyNames()))
+		return k, &exitError{code: 1}
+	}
+	return k, nil
+}
+
+// configValue returns the effective value and source of a setting. Tokens
+// are masked, and a token from the credential store reports that source.
+func configValue(key string) (value, source string) {
+	if key != config.KeyToken {
+		return config.Lookup(key)
+	}
+	value, source = config.Lookup(key)
+	if token := config.GetToken(); token != value {
+		// A top-level token that the active profile does not use, or none
+		value, source = token, ""
+		if token != "" {
+			source = "credential_store"
+		}
+	}
+	return maskToken(value), source
+}
+
+func validateNonEmpty(v string) error {
+	if v == "" {
+		return errors.New("value cannot be empty")
+	}
+	return nil
+}
+
+func validateID(v string) error {
+	if v == "" || strings.ContainsAny(v, " /?#") {
+		return fmt.Errorf("invalid ID %q", v)
+	}
+	return nil
+}
+
+func validateFormat(v string) error {
+	for _, f := range output.Formats() {
+		if f == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (valid: %s)", v, strings.Join(output.Formats(), ", "))
+}
+
+func validateURL(v string) error {
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: expected http(s)://host[/path]", v)
+	}
+	return nil
+}
+
+func validateNonNegativeInt(v string) error {
+	if n, err := strconv.Atoi(v); err != nil || n < 0 {
+		return fmt.Errorf("expected a non-negative integer, got %q", v)
+	}
+	return nil
+}
+
+func validateDuration(v string) error {
+	if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+		return fmt.Errorf("expected a positive duration such as 500ms or 30s, got %q", v)
+	}
+	return nil
+}
+
+func validateOneOf(values ...string) func(string) error {
+	return func(v string) error {
+		for _, allowed := range values {
+			if v == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %s, got %q", strings.Join(values, ", "), v)
+	}
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Show the effective value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := lookupConfigKey(args[0])
+		if err != nil {
+			return err
+		}
+		value, source := configValue(k.name)
+		return output.Print(map[string]string{"key": k.name, "value": value, "source": source})
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Save a setting in the active profile or the top-level config",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := lookupConfigKey(args[0])
+		if err != nil {
+			return err
+		}
+		value := strings.TrimSpace(args[1])
+		// Default space and list may be given by name; the ID is saved.
+		if !resolve.IsID(value) {
+			switch k.name {
+			case config.KeyDefaultSpace:
+				value, err = getResolver(cmd).Space(context.Background(), value)
+			case config.KeyDefaultList:
+				value, err = getResolver(cmd).List(context.Background(), value, "")
+			}
+			if err != nil {
+				return resolveError(k.name, err)
+			}
+		}
+		if err := k.validate(value); err != nil {
+			output.PrintError("VALIDATION_ERROR", fmt.Sprintf("%s: %v", k.name, err))
+			return &exitError{code: 1}
+		}
+		if k.name == config.KeyToken {
+			err = config.SetToken(value)
+		} else {
+			err = config.Set(k.name, value)
+		}
+		if err != nil {
+			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save config: %v", err))
+			return &exitError{code: 1}
+		}
+		if k.name == config.KeyToken {
+			value = maskToken(value)
+		}
+		return output.Print(map[string]string{"key": k.name, "value": value, "profile": config.ActiveProfile()})
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting from the active profile or the top-level config",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := lookupConfigKey(args[0])
+		if err != nil {
+			return err
+		}
+		if k.name == config.KeyToken {
+			err = config.DeleteToken()
+		} else {
+			err = config.Unset(k.name)
+		}
+		if err != nil {
+			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save config: %v", err))
+			return &exitError{code: 1}
+		}
+		return output.Print(map[string]string{"message": fmt.Sprintf("%s unset", k.name), "profile": config.ActiveProfile()})
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings with their effective values",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings := make([]map[string]string, 0, len(configKeys))
+		for _, k := range configKeys {
+			value, source := configValue(k.name)
+			settings = append(settings, map[string]string{
+				"key":         k.name,
+				"value":       value,
+				"source":      source,
+				"description": k.description,
+			})
+		}
+		return output.Print(map[string]interface{}{"profile": config.ActiveProfile(), "settings": settings})
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Show the config and credentials file paths",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return output.Print(map[string]string{
+			"config":      config.ConfigFilePath(),
+			"credentials": config.CredentialsFilePath(),
+		})
+	},
+}
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named profiles",
+	Long:  "Profiles hold a token, workspace, default list and base URL under a name. Select one per command with --profile or CLICKUP_PROFILE, or save it as current with 'config profile use'.",
+}
+
+var configProfileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Create or update a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p := config.Profile{Name: args[0]}
+		p.Token, _ = cmd.Flags().GetString("token")
+		p.Workspace, _ = cmd.Flags().GetString("workspace")
+		p.DefaultList, _ = cmd.Flags().GetString("default-list")
+		p.BaseURL, _ = cmd.Flags().GetString("base-url")
+		if err := config.ValidateProfileName(p.Name); err != nil {
+			output.PrintError("VALIDATION_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		if err := config.SaveProfile(p); err != nil {
+			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save profile: %v", err))
+			return &exitError{code: 1}
+		}
+		use, _ := cmd.Flags().GetBool("use")
+		if use {
+			if err := config.UseProfile(p.Name); err != nil {
+				output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save profile: %v", err))
+				return &exitError{code: 1}
+			}
+		}
+		return output.Print(map[string]interface{}{
+			"message": fmt.Sprintf("profile %s saved", p.Name),
+			"profile": p.Name,
+			"current": use,
+		})
+	},
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the current profile (use \"\" for the top-level settings)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "" && !config.HasProfile(args[0]) {
+			output.PrintError("NOT_FOUND", fmt.Sprintf("profile %q does not exist", args[0]))
+			return &exitError{code: 1}
+		}
+		if err := config.UseProfile(args[0]); err != nil {
+			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save config: %v", err))
+			return &exitError{code: 1}
+		}
+		return output.Print(map[string]string{"message": "current profile updated", "profile": args[0]})
+	},
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		active := config.ActiveProfile()
+		profiles := make([]map[string]interface{}, 0)
+		for _, p := range config.Profiles() {
+			profiles = append(profiles, map[string]interface{}{
+				"name":         p.Name,
+				"active":       p.Name == active,
+				"token":        maskToken(p.Token),
+				"workspace":    p.Workspace,
+				"default_list": p.DefaultList,
+				"base_url":     p.BaseURL,
+			})
+		}
+		return output.Print(map[string]interface{}{"profiles": profiles})
+	},
+}
+
+var configProfileRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Delete a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !config.HasProfile(args[0]) {
+			output.PrintError("NOT_FOUND", fmt.Sprintf("profile %q does not exist", args[0]))
+			return &exitError{code: 1}
+		}
+		if err := config.RemoveProfile(args[0]); err != nil {
+			output.PrintError("CONFIG_ERROR", fmt.Sprintf("failed to save config: %v", err))
+			return &exitError{code: 1}
+		}
+		return output.Print(map[string]string{"message": fmt.Sprintf("profile %s removed", args[0])})
+	},
+}
+
+// maskToken hides a token, keeping only a pk_ prefix (BR-002d).
+func maskToken(token string) string {
+	switch {
+	case token == "":
+		return ""
+	case strings.HasPrefix(token, "pk_"):
+		return "pk_****"
+	default:
+		return "****"
+	}
+}
+
+func init() {
+	configProfileAddCmd.Flags().String("token", "", "API token for this profile")
+	configProfileAddCmd.Flags().String("workspace", "", "Default workspace ID for this profile")
+	configProfileAddCmd.Flags().String("default-list", "", "List used when --list is omitted")
+	configProfileAddCmd.Flags().String("base-url", "", "API base URL (default "+api.DefaultBaseURL+")")
+	configProfileAddCmd.Flags().Bool("use", false, "Also make this the current profile")
+
+	configProfileCmd.AddCommand(configProfileAddCmd, configProfileUseCmd, configProfileListCmd, configProfileRemoveCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configUnsetCmd, configListCmd, configPathCmd, configProfileCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -3,8 +3,13 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/customfield"
 	"github.com/blockful/clickup-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -45,41 +50,146 @@ var customFieldListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
 var customFieldSetCmd = &cobra.Command{
 	Use:   "set",
 	Short: "Set a custom field value on a task",
+	Long: `Set a custom field value on a task. The field is looked up on the task by
+ID or name, and the value is checked against the field's type:
+
+  drop_down          option name or ID: High
+  labels             option names or IDs: "Backend,API"
+  date               2025-01-31, 2025-01-31T09:30, tomorrow, Unix ms
+  number, currency   12.5
+  checkbox           true, false, yes, no
+  emoji (rating)     0 to the field's maximum
+  users              user IDs, emails or usernames; prefix - to remove: "alice,-bob"
+  tasks              task IDs; prefix - to remove
+  manual_progress    a number between the field's start and end
+  location           LAT,LNG or LAT,LNG,ADDRESS
+  url, email, phone  validated text; phone numbers need a country code
+
+A JSON object is sent as it is. --raw skips the lookup and sends the value
+as JSON, or as a string when it is not valid JSON.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
 		taskID, _ := cmd.Flags().GetString("task")
 		fieldID, _ := cmd.Flags().GetString("field")
 		value, _ := cmd.Flags().GetString("value")
+		raw, _ := cmd.Flags().GetBool("raw")
 
 		if taskID == "" || fieldID == "" || value == "" {
 			output.PrintError("VALIDATION_ERROR", "--task, --field, and --value are required")
 			return &exitError{code: 1}
 		}
 
-		// Try to parse value as JSON, fall back to string
-		var v interface{}
-		if err := json.Unmarshal([]byte(value), &v); err != nil {
-			v = value
+		var req *api.SetCustomFieldRequest
+		if raw {
+			var v interface{}
+			if err := json.Unmarshal([]byte(value), &v); err != nil {
+				v = value
+			}
+			req = &api.SetCustomFieldRequest{Value: v}
+		} else {
+			opts := api.GetTaskOptions{}
+			if o := getTaskScopedOpts(cmd); o != nil {
+				opts.CustomTaskIDs, opts.TeamID = o.CustomTaskIDs, o.TeamID
+			}
+			task, err := client.GetTask(ctx, taskID, opts)
+			if err != nil {
+				return handleError(err)
+			}
+			field, err := customfield.Find(task.CustomFields, fieldID)
+			if err != nil {
+				return resolveError("--field", err)
+			}
+			if req, err = encodeCustomField(cmd, field, value, task.List.ID); err != nil {
+				return err
+			}
+			fieldID = field.ID
 		}
 
-		req := &api.SetCustomFieldRequest{Value: v}
 		if err := client.SetCustomFieldValue(ctx, taskID, fieldID, req, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
+// encodeCustomField encodes value for field with the field's codec, reading
+// dates like date flags and resolving user names among the members of
+// listID. An invalid value prints a VALIDATION_ERROR listing the choices
+// when the field has a fixed set.
+func encodeCustomField(cmd *cobra.Command, field api.CustomField, value, listID string) (*api.SetCustomFieldRequest, error) {
+	codec := &customfield.Codec{
+		ParseDate: func(s string) (time.Time, error) {
+			return parseDate(s, time.Now())
+		},
+		ResolveUser: func(ref string) (int, error) {
+			return getResolver(cmd).User(context.Background(), ref, listID)
+		},
+	}
+	req, err := codec.Encode(field, value)
+	var ce *customfield.Error
+	switch {
+	case errors.As(err, &ce):
+		var choices interface{}
+		if len(ce.Choices) > 0 {
+			choices = ce.Choices
+		}
+		output.PrintErrorCandidates("VALIDATION_ERROR", ce.Error(), choices)
+		return nil, &exitError{code: 1}
+	case err != nil:
+		return nil, resolveError("--field "+field.Name, err)
+	}
+	return req, nil
+}
+
+// getFieldValues encodes the "Name=Value" pairs in the --field flag for the
+// custom fields of listID, fetched with client. Fields match by name or ID,
+// like custom-field set. It returns nil without an API call when the flag is
+// not given.
+func getFieldValues(cmd *cobra.Command, client api.ClientInterface, listID string) ([]api.CustomFieldValue, error) {
+	pairs, _ := cmd.Flags().GetStringArray("field")
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	resp, err := client.GetListCustomFields(context.Background(), listID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	values := make([]api.CustomFieldValue, 0, len(pairs))
+	seen := map[string]bool{}
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			output.PrintError("VALIDATION_ERROR", fmt.Sprintf("--field %q: expected NAME=VALUE", pair))
+			return nil, &exitError{code: 1}
+		}
+		field, err := customfield.Find(resp.Fields, name)
+		if err != nil {
+			return nil, resolveError("--field", err)
+		}
+		if seen[field.ID] {
+			output.PrintError("VALIDATION_ERROR", fmt.Sprintf("--field: %s is given more than once", field.Name))
+			return nil, &exitError{code: 1}
+		}
+		seen[field.ID] = true
+		req, err := encodeCustomField(cmd, field, value, listID)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, api.CustomFieldValue{ID: field.ID, Value: req.Value, ValueOptions: req.ValueOptions})
+	}
+	return values, nil
+}
+
 var customFieldRemoveCmd = &cobra.Command{
 	Use:   "remove",
 	Short: "Remove a custom field value from a task",
@@ -97,8 +207,7 @@ var customFieldRemoveCmd = &cobra.Command{
 		if err := client.RemoveCustomFieldValue(ctx, taskID, fieldID, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -108,14 +217,15 @@ func init() {
 	customFieldCmd.AddCommand(customFieldSetCmd)
 	customFieldCmd.AddCommand(customFieldRemoveCmd)
 
-	customFieldListCmd.Flags().String("list", "", "List ID")
-	customFieldListCmd.Flags().String("folder", "", "Folder ID")
-	customFieldListCmd.Flags().String("space", "", "Space ID")
+	customFieldListCmd.Flags().String("list", "", "List ID or name")
+	customFieldListCmd.Flags().String("folder", "", "Folder ID or name")
+	customFieldListCmd.Flags().String("space", "", "Space ID or name")
 	customFieldListCmd.Flags().String("workspace", "", "Workspace ID")
 
 	customFieldSetCmd.Flags().String("task", "", "Task ID (required)")
-	customFieldSetCmd.Flags().String("field", "", "Field ID (required)")
-	customFieldSetCmd.Flags().String("value", "", "Field value as JSON or string (required)")
+	customFieldSetCmd.Flags().String("field", "", "Field ID or name (required)")
+	customFieldSetCmd.Flags().String("value", "", "Field value, checked against the field's type (required)")
+	customFieldSetCmd.Flags().Bool("raw", false, "Send --value as JSON or a string without looking up the field")
 	addTaskScopedFlags(customFieldSetCmd)
 
 	customFieldRemoveCmd.Flags().String("task", "", "Task ID (required)")
@@ -23,8 +23,7 @@ var customTaskTypeListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
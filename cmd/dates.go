@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// dateLayouts are the ISO 8601 forms accepted by parseDate. Layouts without
+// an offset are read in the local time zone, or in a trailing IANA zone.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var relativeDate = regexp.MustCompile(`^([+-]?)(\d+)(m|h|d|w)$`)
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// parseDate turns a date flag value into a time, relative to now and in
+// now's location unless the value names another zone. It accepts:
+//
+//   - Unix milliseconds: 1767225600000
+//   - ISO 8601: 2026-03-01, 2026-03-01T09:30, 2026-03-01T09:30:00Z,
+//     2026-03-01T09:30:00-03:00, optionally followed by an IANA zone
+//     ("2026-03-01 09:30 Europe/Berlin")
+//   - now, today, tomorrow, yesterday
+//   - offsets from now in minutes, hours, days or weeks: -7d, +2w, 90m
+//   - weekdays: friday (today if it is Friday), next friday, last friday
+//   - ends of periods: eod, eow (Sunday), eom
+//
+// Named days resolve to the start of the day; eod, eow and eom to its last
+// millisecond.
+func parseDate(s string, now time.Time) (time.Time, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.UnixMilli(ms).In(now.Location()), nil
+	}
+
+	day := startOfDay(now)
+	endOf := func(t time.Time) time.Time { return t.Add(-time.Millisecond) }
+	switch v {
+	case "now":
+		return now, nil
+	case "today":
+		return day, nil
+	case "tomorrow":
+		return day.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return day.AddDate(0, 0, -1), nil
+	case "eod":
+		return endOf(day.AddDate(0, 0, 1)), nil
+	case "eow":
+		return endOf(day.AddDate(0, 0, 1+daysUntil(now.Weekday(), time.Sunday))), nil
+	case "eom":
+		return endOf(time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())), nil
+	}
+
+	if m := relativeDate.FindStringSubmatch(v); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		switch m[3] {
+		case "m":
+			return now.Add(time.Duration(n) * time.Minute), nil
+		case "h":
+			return now.Add(time.Duration(n) * time.Hour), nil
+		case "d":
+			return now.AddDate(0, 0, n), nil
+		default:
+			return now.AddDate(0, 0, 7*n), nil
+		}
+	}
+
+	words := strings.Fields(v)
+	if len(words) <= 2 {
+		if wd, ok := weekdays[words[len(words)-1]]; ok {
+			ahead := daysUntil(now.Weekday(), wd)
+			switch {
+			case len(words) == 1:
+			case words[0] == "next":
+				if ahead == 0 {
+					ahead = 7
+				}
+			case words[0] == "last":
+				ahead -= 7
+			default:
+				return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+			}
+			return day.AddDate(0, 0, ahead), nil
+		}
+	}
+
+	loc := now.Location()
+	value := strings.TrimSpace(s)
+	if i := strings.LastIndex(value, " "); i > 0 {
+		if name := value[i+1:]; strings.Contains(name, "/") || name == "UTC" {
+			zone, err := time.LoadLocation(name)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("unknown time zone %q", name)
+			}
+			loc, value = zone, strings.TrimSpace(value[:i])
+		}
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q (use ISO 8601 such as 2026-03-01 or 2026-03-01T09:30, Unix ms, or today, yesterday, -7d, next friday, eow)", s)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysUntil returns how many days from from until the next to, 0 to 6.
+func daysUntil(from, to time.Weekday) int {
+	return (int(to) - int(from) + 7) % 7
+}
+
+// getDate reads a date flag as Unix milliseconds, returning 0 when it is
+// empty. An unparseable value prints a VALIDATION_ERROR.
+func getDate(cmd *cobra.Command, name string) (int64, error) {
+	v, _ := cmd.Flags().GetString(name)
+	if v == "" {
+		return 0, nil
+	}
+	t, err := parseDate(v, time.Now())
+	if err != nil {
+		output.PrintError("VALIDATION_ERROR", fmt.Sprintf("--%s: %v", name, err))
+		return 0, &exitError{code: 1}
+	}
+	return t.UnixMilli(), nil
+}
+
+// getDates reads several date flags into dst, keyed by flag name, stopping
+// at the first invalid value in flag name order.
+func getDates(cmd *cobra.Command, dst map[string]*int64) error {
+	names := make([]string, 0, len(dst))
+	for name := range dst {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v, err := getDate(cmd, name)
+		if err != nil {
+			return err
+		}
+		*dst[name] = v
+	}
+	return nil
+}
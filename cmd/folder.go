@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/impact"
 	"github.com/blockful/clickup-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -19,17 +20,16 @@ var folderListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		spaceID, _ := cmd.Flags().GetString("space")
+		spaceID := getSpaceID(cmd)
 		if spaceID == "" {
-			output.PrintError("VALIDATION_ERROR", "--space is required")
+			output.PrintError("VALIDATION_ERROR", "--space is required (or set a default with 'clickup config set default_space <id>')")
 			return &exitError{code: 1}
 		}
 		resp, err := client.ListFolders(ctx, spaceID)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -48,8 +48,7 @@ var folderGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -59,9 +58,9 @@ var folderCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		spaceID, _ := cmd.Flags().GetString("space")
+		spaceID := getSpaceID(cmd)
 		if spaceID == "" {
-			output.PrintError("VALIDATION_ERROR", "--space is required")
+			output.PrintError("VALIDATION_ERROR", "--space is required (or set a default with 'clickup config set default_space <id>')")
 			return &exitError{code: 1}
 		}
 		name, _ := cmd.Flags().GetString("name")
@@ -73,8 +72,7 @@ var folderCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -98,8 +96,7 @@ var folderUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -108,28 +105,26 @@ var folderDeleteCmd = &cobra.Command{
 	Short: "Delete a folder",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
-		ctx := context.Background()
 		id, _ := cmd.Flags().GetString("id")
 		if id == "" {
 			output.PrintError("VALIDATION_ERROR", "--id is required")
 			return &exitError{code: 1}
 		}
-		if err := client.DeleteFolder(ctx, id); err != nil {
-			return handleError(err)
-		}
-		output.JSON(map[string]string{"message": "folder deleted", "id": id})
-		return nil
+		return deleteContainer(cmd, client, impact.TypeFolder, id, func(ctx context.Context) error {
+			return client.DeleteFolder(ctx, id)
+		})
 	},
 }
 
 func init() {
-	folderListCmd.Flags().String("space", "", "Space ID")
+	folderListCmd.Flags().String("space", "", "Space ID or name (defaults to the default_space setting)")
 	folderGetCmd.Flags().String("id", "", "Folder ID")
-	folderCreateCmd.Flags().String("space", "", "Space ID")
+	folderCreateCmd.Flags().String("space", "", "Space ID or name (defaults to the default_space setting)")
 	folderCreateCmd.Flags().String("name", "", "Folder name")
 	folderUpdateCmd.Flags().String("id", "", "Folder ID")
 	folderUpdateCmd.Flags().String("name", "", "Folder name")
 	folderDeleteCmd.Flags().String("id", "", "Folder ID")
+	addDeleteFlags(folderDeleteCmd)
 
 	folderCmd.AddCommand(folderListCmd)
 	folderCmd.AddCommand(folderGetCmd)
@@ -25,8 +25,7 @@ var goalListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -45,8 +44,7 @@ var goalGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -58,7 +56,10 @@ var goalCreateCmd = &cobra.Command{
 		ctx := context.Background()
 		wid := getWorkspaceID(cmd)
 		name, _ := cmd.Flags().GetString("name")
-		dueDate, _ := cmd.Flags().GetInt64("due-date")
+		dueDate, err := getDate(cmd, "due-date")
+		if err != nil {
+			return err
+		}
 		description, _ := cmd.Flags().GetString("description")
 		color, _ := cmd.Flags().GetString("color")
 		multipleOwners, _ := cmd.Flags().GetBool("multiple-owners")
@@ -82,8 +83,7 @@ var goalCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -109,6 +109,13 @@ var goalUpdateCmd = &cobra.Command{
 		if cmd.Flags().Changed("color") {
 			req.Color, _ = cmd.Flags().GetString("color")
 		}
+		if cmd.Flags().Changed("due-date") {
+			v, err := getDate(cmd, "due-date")
+			if err != nil {
+				return err
+			}
+			req.DueDate = api.Int64Ptr(v)
+		}
 		if cmd.Flags().Changed("rem-owners") {
 			req.RemOwners, _ = cmd.Flags().GetIntSlice("rem-owners")
 		}
@@ -120,8 +127,7 @@ var goalUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -139,8 +145,7 @@ var goalDeleteCmd = &cobra.Command{
 		if err := client.DeleteGoal(ctx, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -187,8 +192,7 @@ var keyResultCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -215,8 +219,7 @@ var keyResultUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -234,8 +237,7 @@ var keyResultDeleteCmd = &cobra.Command{
 		if err := client.DeleteKeyResult(ctx, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -265,7 +267,7 @@ func init() {
 	goalGetCmd.Flags().String("id", "", "Goal ID (required)")
 
 	goalCreateCmd.Flags().String("name", "", "Goal name (required)")
-	goalCreateCmd.Flags().Int64("due-date", 0, "Due date (unix ms)")
+	goalCreateCmd.Flags().String("due-date", "", "Due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	goalCreateCmd.Flags().String("description", "", "Description")
 	goalCreateCmd.Flags().String("color", "", "Color hex")
 	goalCreateCmd.Flags().Bool("multiple-owners", false, "Allow multiple owners")
@@ -275,6 +277,7 @@ func init() {
 	goalUpdateCmd.Flags().String("name", "", "New name")
 	goalUpdateCmd.Flags().String("description", "", "New description")
 	goalUpdateCmd.Flags().String("color", "", "New color")
+	goalUpdateCmd.Flags().String("due-date", "", "New due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	goalUpdateCmd.Flags().IntSlice("rem-owners", nil, "Owner IDs to remove")
 	goalUpdateCmd.Flags().IntSlice("add-owners", nil, "Owner IDs to add")
 
@@ -2,16 +2,27 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/config"
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/blockful/clickup-cli/internal/testutil"
+	"github.com/blockful/clickup-cli/internal/webhook"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -29,6 +40,11 @@ func runCommand(t *testing.T, serverURL string, args ...string) (string, error)
 	}
 	defer func() { clientFactory = oldFactory }()
 
+	// Keep each command's hierarchy cache to itself, unless the test shares one
+	if os.Getenv("CLICKUP_CACHE_DIR") == "" {
+		t.Setenv("CLICKUP_CACHE_DIR", t.TempDir())
+	}
+
 	// Set workspace in viper for commands that need it
 	viper.Set("workspace", "12345678")
 	defer viper.Reset()
@@ -51,6 +67,21 @@ func runCommand(t *testing.T, serverURL string, args ...string) (string, error)
 	return buf.String(), err
 }
 
+// captureStderr returns what fn writes to stderr, such as a printed error.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	fn()
+	w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	return buf.String()
+}
+
 // requestLog captures HTTP requests for verification.
 type requestLog struct {
 	mu      sync.Mutex
@@ -330,6 +361,11 @@ func TestTaskCreate(t *testing.T) {
 func TestTaskUpdate(t *testing.T) {
 	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
+		// --status is matched against the statuses of the task's list.
+		if r.URL.Path == "/api/v2/list/901100200300" {
+			_, _ = w.Write([]byte(`{"id":"901100200300","statuses":[{"status":"to do"},{"status":"complete"}]}`))
+			return
+		}
 		_ = json.NewEncoder(w).Encode(map[string]interface{}{
 			"id":           "abc123def",
 			"name":         "Updated task name",
@@ -357,6 +393,7 @@ func TestTaskUpdate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	defer func() { taskUpdateCmd.Flags().Lookup("status").Changed = false }()
 
 	if log.Method != "PUT" {
 		t.Errorf("expected PUT, got %s", log.Method)
@@ -377,6 +414,153 @@ func TestTaskUpdate(t *testing.T) {
 	mustContainJSON(t, out, "name", "Updated task name")
 }
 
+func TestTaskFieldFlag(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	fieldBodies := map[string]string{}
+	failPoints := false
+	var log *requestLog
+	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if strings.Contains(r.URL.Path, "/field/") {
+			fieldBodies[r.URL.Path] = log.Body
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/task/t1/field/cf_points":
+			if failPoints {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"err":"Value is not a valid number","ECODE":"FIELD_011"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case "/api/v2/list/100/field":
+			_, _ = w.Write([]byte(`{"fields":[
+				{"id":"cf_points","name":"Story Points","type":"number"},
+				{"id":"cf_team","name":"Team","type":"drop_down","type_config":{"options":[{"id":"opt_platform","name":"Platform","orderindex":0}]}}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"t1","name":"Fix login","list":{"id":"100"}}`))
+		}
+	})
+	defer func() {
+		for _, c := range []*cobra.Command{taskCreateCmd, taskUpdateCmd} {
+			f := c.Flags().Lookup("field")
+			_ = f.Value.(pflag.SliceValue).Replace(nil)
+			f.Changed = false
+		}
+		_ = taskCreateCmd.Flags().Set("custom-fields", "")
+		taskCreateCmd.Flags().Lookup("custom-fields").Changed = false
+		_ = rootCmd.PersistentFlags().Set("dry-run", "false")
+		rootCmd.PersistentFlags().Lookup("dry-run").Changed = false
+	}()
+	for _, name := range []string{"name", "priority"} {
+		f := taskUpdateCmd.Flags().Lookup(name)
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+		defer func() {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}()
+	}
+
+	// task create sends the encoded values in the create request
+	_, err := runCommand(t, server.URL, "task", "create", "--list", "100", "--name", "Fix login",
+		"--field", "Story Points=5", "--field", "team=Platform")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Path != "/api/v2/list/100/task" {
+		t.Errorf("unexpected path: %s", log.Path)
+	}
+	if !strings.Contains(log.Body, `"custom_fields":[{"id":"cf_points","value":5},{"id":"cf_team","value":"opt_platform"}]`) {
+		t.Errorf("expected encoded custom fields, got %s", log.Body)
+	}
+
+	// A field given by both --custom-fields and --field is rejected
+	requests = nil
+	_ = taskCreateCmd.Flags().Lookup("field").Value.(pflag.SliceValue).Replace(nil)
+	_, err = runCommand(t, server.URL, "task", "create", "--list", "100", "--name", "Fix login",
+		"--custom-fields", `[{"id":"cf_team","value":"opt_other"}]`, "--field", "Team=Platform")
+	if err == nil {
+		t.Fatal("expected an error for a field given twice")
+	}
+	for _, req := range requests {
+		if !strings.HasPrefix(req, "GET ") {
+			t.Errorf("expected no changes, got %s", req)
+		}
+	}
+	_ = taskCreateCmd.Flags().Set("custom-fields", "")
+
+	// With only --field, task update sets each field on the task's list
+	// without an empty update, and prints the refetched task
+	requests = nil
+	_ = taskUpdateCmd.Flags().Lookup("field").Value.(pflag.SliceValue).Replace(nil)
+	_, err = runCommand(t, server.URL, "task", "update", "--id", "t1", "--field", "Team=Platform")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"GET /api/v2/task/t1", "GET /api/v2/list/100/field", "POST /api/v2/task/t1/field/cf_team", "GET /api/v2/task/t1"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("got requests %v, want %v", requests, want)
+	}
+	if got := fieldBodies["/api/v2/task/t1/field/cf_team"]; got != `{"value":"opt_platform"}` {
+		t.Errorf("unexpected field body: %s", got)
+	}
+
+	// An invalid value fails before the task is changed
+	requests = nil
+	_ = taskUpdateCmd.Flags().Lookup("field").Value.(pflag.SliceValue).Replace(nil)
+	_, err = runCommand(t, server.URL, "task", "update", "--id", "t1", "--field", "Team=Platform", "--field", "Story Points=lots")
+	if err == nil {
+		t.Fatal("expected an error for an invalid number")
+	}
+	for _, req := range requests {
+		if !strings.HasPrefix(req, "GET ") {
+			t.Errorf("expected no changes, got %s", req)
+		}
+	}
+
+	// A dry run previews the update and every field
+	requests = nil
+	_ = taskUpdateCmd.Flags().Lookup("field").Value.(pflag.SliceValue).Replace(nil)
+	out, err := runCommand(t, server.URL, "task", "update", "--id", "t1", "--name", "Fix logout",
+		"--field", "Team=Platform", "--field", "Story Points=3", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `{"dry_run":true,"method":"PUT","path":"/v2/task/t1","body":{"name":"Fix logout"},"fields":[
+		{"method":"POST","path":"/v2/task/t1/field/cf_team","body":{"value":"opt_platform"}},
+		{"method":"POST","path":"/v2/task/t1/field/cf_points","body":{"value":3}}]}`, out)
+	for _, req := range requests {
+		if !strings.HasPrefix(req, "GET ") {
+			t.Errorf("expected no changes in a dry run, got %s", req)
+		}
+	}
+	_ = rootCmd.PersistentFlags().Set("dry-run", "false")
+
+	// A field that fails after the update says what was already applied
+	requests = nil
+	failPoints = true
+	_ = taskUpdateCmd.Flags().Lookup("field").Value.(pflag.SliceValue).Replace(nil)
+	stderr := captureStderr(t, func() {
+		_, err = runCommand(t, server.URL, "task", "update", "--id", "t1", "--name", "Fix logout",
+			"--field", "Team=Platform", "--field", "Story Points=3")
+	})
+	if err == nil {
+		t.Fatal("expected an error for the failing field")
+	}
+	want = []string{"GET /api/v2/task/t1", "GET /api/v2/list/100/field", "PUT /api/v2/task/t1",
+		"POST /api/v2/task/t1/field/cf_team", "POST /api/v2/task/t1/field/cf_points"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("got requests %v, want %v", requests, want)
+	}
+	if !strings.Contains(stderr, "custom field cf_points: Value is not a valid number; already applied: the task update and custom fields cf_team") {
+		t.Errorf("expected the error to list what was applied, got %s", stderr)
+	}
+}
+
 // --- Comment Create (tests json.Number for numeric ID) ---
 
 func TestCommentCreate(t *testing.T) {
@@ -503,6 +687,11 @@ func TestCustomFieldList(t *testing.T) {
 
 func TestCustomFieldSet(t *testing.T) {
 	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"abc123def","list":{"id":"100"},"custom_fields":[{"id":"cf_uuid_sprint","name":"Sprint","type":"short_text"}]}`))
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -531,6 +720,64 @@ func TestCustomFieldSet(t *testing.T) {
 	mustContainJSON(t, out, "status", "ok")
 }
 
+func TestCustomFieldSetByType(t *testing.T) {
+	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"abc123def","list":{"id":"100"},"custom_fields":[
+				{"id":"cf_priority","name":"Priority","type":"drop_down","type_config":{"options":[{"id":"opt_high","name":"High","orderindex":0},{"id":"opt_low","name":"Low","orderindex":1}]}},
+				{"id":"cf_due","name":"Launch","type":"date"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	raw := customFieldSetCmd.Flags().Lookup("raw")
+	defer func() {
+		_ = raw.Value.Set("false")
+		raw.Changed = false
+	}()
+
+	// An option name is sent as the option's ID
+	_, err := runCommand(t, server.URL, "custom-field", "set", "--task", "abc123def", "--field", "priority", "--value", "High")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Method != "POST" || log.Path != "/api/v2/task/abc123def/field/cf_priority" {
+		t.Errorf("unexpected request: %s %s", log.Method, log.Path)
+	}
+	if !strings.Contains(log.Body, `"value":"opt_high"`) {
+		t.Errorf("expected the option ID, got %s", log.Body)
+	}
+
+	// A date is sent in milliseconds
+	_, err = runCommand(t, server.URL, "custom-field", "set", "--task", "abc123def", "--field", "Launch", "--value", "2025-01-31")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := fmt.Sprintf(`"value":%d`, time.Date(2025, 1, 31, 0, 0, 0, 0, time.Local).UnixMilli())
+	if !strings.Contains(log.Body, want) {
+		t.Errorf("expected %s, got %s", want, log.Body)
+	}
+
+	// An unknown option fails before anything is sent
+	_, err = runCommand(t, server.URL, "custom-field", "set", "--task", "abc123def", "--field", "Priority", "--value", "Urgent")
+	if err == nil {
+		t.Fatal("expected an error for an unknown option")
+	}
+	if log.Method != "GET" {
+		t.Errorf("expected no value to be sent, last request was %s %s", log.Method, log.Path)
+	}
+
+	// --raw sends the value as it is, without a lookup
+	_, err = runCommand(t, server.URL, "custom-field", "set", "--task", "abc123def", "--field", "cf_priority", "--value", "Urgent", "--raw")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Method != "POST" || !strings.Contains(log.Body, `"value":"Urgent"`) {
+		t.Errorf("expected the raw value to be sent, got %s %s", log.Method, log.Body)
+	}
+}
+
 // --- Doc List (tests json.Number for date_created) ---
 
 func TestDocList(t *testing.T) {
@@ -798,6 +1045,7 @@ func TestTaskListWithFilters(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(map[string]interface{}{"tasks": []interface{}{}})
 	})
+	defer func() { _ = taskListCmd.Flags().Lookup("status").Value.(pflag.SliceValue).Replace(nil) }()
 
 	_, err := runCommand(t, server.URL, "task", "list",
 		"--list", "901100200300",
@@ -866,3 +1114,1099 @@ func TestOutputIsValidJSON(t *testing.T) {
 		})
 	}
 }
+
+// --- Text Output ---
+
+func TestTaskListTextFormat(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tasks":[{"id":"abc123def","name":"Implement auth flow","status":{"status":"in progress"},"assignees":[{"id":1,"username":"alice"}],"tags":[]}]}`))
+	})
+	defer func() { _ = rootCmd.PersistentFlags().Set("format", "json") }()
+
+	out, err := runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--format", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if json.Valid([]byte(strings.TrimSpace(out))) {
+		t.Fatalf("expected text output, got JSON:\n%s", out)
+	}
+	for _, want := range []string{"STATUS", "abc123def", "Implement auth flow", "in progress", "alice"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for an invalid format")
+	})
+	defer func() { _ = rootCmd.PersistentFlags().Set("format", "json") }()
+
+	if _, err := runCommand(t, server.URL, "workspace", "list", "--format", "yaml"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+// --- Field Projection ---
+
+func TestTaskListFieldsAndQuery(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tasks":[
+			{"id":"a1","name":"Open task","status":{"status":"open","color":"#000"},"assignees":[],"tags":[]},
+			{"id":"b2","name":"Done task","status":{"status":"done","color":"#0f0"},"assignees":[],"tags":[]}
+		]}`))
+	})
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("fields", "")
+		_ = rootCmd.PersistentFlags().Set("query", "")
+	}()
+
+	out, err := runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--fields", "id,status.status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var projected struct {
+		Tasks []map[string]interface{} `json:"tasks"`
+	}
+	if err := json.Unmarshal([]byte(out), &projected); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(projected.Tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(projected.Tasks))
+	}
+	if _, ok := projected.Tasks[0]["name"]; ok {
+		t.Errorf("name should have been projected away: %s", out)
+	}
+	if status, _ := projected.Tasks[0]["status"].(map[string]interface{}); status["status"] != "open" || status["color"] != nil {
+		t.Errorf("unexpected status projection: %v", projected.Tasks[0]["status"])
+	}
+
+	out, err = runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--fields", "", "--query", "tasks[?status.status==done].id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `["b2"]`, out)
+}
+
+func TestInvalidQuery(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for an invalid query")
+	})
+	defer func() { _ = rootCmd.PersistentFlags().Set("query", "") }()
+
+	if _, err := runCommand(t, server.URL, "workspace", "list", "--query", "teams["); err == nil {
+		t.Fatal("expected error for invalid query")
+	}
+}
+
+// --- Pagination ---
+
+func TestTaskListAll(t *testing.T) {
+	var pages []string
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json")
+		switch page {
+		case "":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t1","name":"one"}],"last_page":false}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t2","name":"two"}],"last_page":false}`))
+		default:
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t3","name":"three"}],"last_page":true}`))
+		}
+	})
+	defer func() {
+		_ = taskListCmd.Flags().Set("all", "false")
+		_ = taskListCmd.Flags().Set("max-pages", "0")
+		_ = taskListCmd.Flags().Set("page", "0")
+	}()
+
+	out, err := runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--page", "0", "--all")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `["t1","t2","t3"]`, mustQuery(t, out, "tasks.id"))
+	if len(pages) != 3 {
+		t.Errorf("expected 3 requests, got %v", pages)
+	}
+
+	pages = nil
+	out, err = runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--all", "--max-pages", "2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `["t1","t2"]`, mustQuery(t, out, "tasks.id"))
+	testutil.AssertJSONEqual(t, `false`, mustQuery(t, out, "last_page"))
+
+	pages = nil
+	if _, err := runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--all", "--max-pages", "-1"); err == nil {
+		t.Error("expected an error for a negative --max-pages")
+	}
+	if len(pages) != 0 {
+		t.Errorf("expected no requests, got %v", pages)
+	}
+}
+
+func TestTemplateListAll(t *testing.T) {
+	empty := false
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if empty {
+			_, _ = w.Write([]byte(`{"templates":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"templates":[{"id":"tpl1","name":"Bug"}]}`))
+	})
+	defer func() {
+		_ = templateListCmd.Flags().Set("all", "false")
+		_ = templateListCmd.Flags().Set("max-pages", "0")
+	}()
+
+	// A walk cut off by --max-pages is reported as incomplete
+	out, err := runCommand(t, server.URL, "template", "list", "--all", "--max-pages", "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `["tpl1"]`, mustQuery(t, out, "templates.id"))
+	testutil.AssertJSONEqual(t, `false`, mustQuery(t, out, "last_page"))
+
+	// No results print an empty array, not null
+	empty = true
+	out, err = runCommand(t, server.URL, "template", "list", "--all", "--max-pages", "0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `[]`, mustQuery(t, out, "templates"))
+	testutil.AssertJSONEqual(t, `true`, mustQuery(t, out, "last_page"))
+}
+
+// mustQuery evaluates a query expression against JSON command output.
+func mustQuery(t *testing.T, out, expr string) string {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	q, err := output.ParseQuery(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(q.Eval(v))
+	return string(data)
+}
+
+func TestTaskListAllNDJSON(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t1"},{"id":"t2"}],"last_page":false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"tasks":[{"id":"t3"}],"last_page":true}`))
+	})
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("format", "json")
+		_ = taskListCmd.Flags().Set("all", "false")
+	}()
+
+	out, err := runCommand(t, server.URL, "task", "list", "--list", "901100200300", "--page", "0", "--all", "--format", "ndjson")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 NDJSON lines, got %d:\n%s", len(lines), out)
+	}
+	for i, want := range []string{"t1", "t2", "t3"} {
+		var task api.Task
+		if err := json.Unmarshal([]byte(lines[i]), &task); err != nil {
+			t.Fatalf("line %d is not a task: %v", i, err)
+		}
+		if task.ID != want {
+			t.Errorf("line %d: expected %s, got %s", i, want, task.ID)
+		}
+	}
+}
+
+// --- CSV Output ---
+
+func TestTimeEntryListCSV(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"te1","task":{"id":"t1","name":"Fix, bug"},"user":{"id":1,"username":"alice"},"billable":false,"start":"1700000000000","duration":"3600000","description":""}]}`))
+	})
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("format", "json")
+		_ = rootCmd.PersistentFlags().Set("columns", "")
+	}()
+
+	out, err := runCommand(t, server.URL, "time-entry", "list", "--format", "csv", "--columns", "id,task,duration")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "id,task,duration\nte1,\"Fix, bug\",1:00:00\n"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
+
+// isolateConfig points the config file and credential store at a temporary
+// home directory.
+func isolateConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CLICKUP_PROFILE", "")
+	t.Setenv("CLICKUP_CREDENTIAL_STORE", "file")
+	t.Setenv("CLICKUP_PASSPHRASE", "test passphrase")
+	config.SetCredentialStore(nil)
+	t.Cleanup(func() { config.SetCredentialStore(nil) })
+}
+
+func TestConfigProfile(t *testing.T) {
+	isolateConfig(t)
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("profile", "")
+		_ = configProfileAddCmd.Flags().Set("use", "false")
+		_ = configProfileAddCmd.Flags().Set("token", "")
+		_ = configProfileAddCmd.Flags().Set("default-list", "")
+		config.SetProfile("")
+	}()
+
+	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tasks":[],"last_page":true}`))
+	})
+
+	if _, err := runCommand(t, server.URL, "config", "profile", "add", "acme", "--token", "pk_acme_secret", "--default-list", "900100", "--use"); err != nil {
+		t.Fatalf("profile add failed: %v", err)
+	}
+	_ = configProfileAddCmd.Flags().Set("use", "false")
+	_ = configProfileAddCmd.Flags().Set("token", "")
+	if _, err := runCommand(t, server.URL, "config", "profile", "add", "own", "--default-list", "900200"); err != nil {
+		t.Fatalf("profile add failed: %v", err)
+	}
+
+	out, err := runCommand(t, server.URL, "config", "profile", "list")
+	if err != nil {
+		t.Fatalf("profile list failed: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `["acme"]`, mustQuery(t, out, "profiles[?active==true].name"))
+	testutil.AssertJSONEqual(t, `["pk_****",""]`, mustQuery(t, out, "profiles.token"))
+
+	// The current profile's default list is used when --list is omitted.
+	_ = taskListCmd.Flags().Set("list", "")
+	if _, err := runCommand(t, server.URL, "task", "list"); err != nil {
+		t.Fatalf("task list failed: %v", err)
+	}
+	if log.Path != "/api/v2/list/900100/task" {
+		t.Errorf("expected default list of current profile, got %s", log.Path)
+	}
+
+	// --profile overrides the current profile.
+	if _, err := runCommand(t, server.URL, "--profile", "own", "task", "list"); err != nil {
+		t.Fatalf("task list failed: %v", err)
+	}
+	if log.Path != "/api/v2/list/900200/task" {
+		t.Errorf("expected default list of --profile, got %s", log.Path)
+	}
+
+	_, err = runCommand(t, server.URL, "--profile", "missing", "task", "list")
+	if err == nil {
+		t.Error("expected error for unknown profile")
+	}
+	_ = rootCmd.PersistentFlags().Set("profile", "")
+
+	if _, err := runCommand(t, server.URL, "config", "profile", "remove", "acme"); err != nil {
+		t.Fatalf("profile remove failed: %v", err)
+	}
+	out, _ = runCommand(t, server.URL, "config", "profile", "list")
+	testutil.AssertJSONEqual(t, `["own"]`, mustQuery(t, out, "profiles.name"))
+}
+
+func TestAuthLoginLogout(t *testing.T) {
+	isolateConfig(t)
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "pk_login_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"err":"Token invalid","ECODE":"OAUTH_025"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user":{"id":1,"username":"alice"}}`))
+	})
+	t.Setenv("CLICKUP_BASE_URL", server.URL+"/api")
+	defer func() { _ = authLoginCmd.Flags().Set("token", "") }()
+
+	out, err := runCommand(t, server.URL, "auth", "login", "--token", "pk_login_token")
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	mustContainJSON(t, out, "credential_store", `"credential_store": "file"`)
+
+	data, _ := os.ReadFile(config.ConfigFilePath())
+	if strings.Contains(string(data), "pk_login_token") {
+		t.Errorf("token written to the config file:\n%s", data)
+	}
+	data, err = os.ReadFile(config.CredentialsFilePath())
+	if err != nil {
+		t.Fatalf("credentials file not written: %v", err)
+	}
+	if strings.Contains(string(data), "pk_login_token") {
+		t.Error("credentials file holds the token in plaintext")
+	}
+
+	// A fresh process finds the token in the store.
+	config.SetCredentialStore(nil)
+	if _, err := runCommand(t, server.URL, "config", "profile", "list"); err != nil {
+		t.Fatal(err)
+	}
+	if got := config.GetToken(); got != "pk_login_token" {
+		t.Errorf("expected stored token, got %q", got)
+	}
+
+	if _, err := runCommand(t, server.URL, "auth", "logout"); err != nil {
+		t.Fatalf("logout failed: %v", err)
+	}
+	config.SetCredentialStore(nil)
+	if got := config.GetToken(); got != "" {
+		t.Errorf("expected no token after logout, got %q", got)
+	}
+}
+
+func TestAuthLoginOAuth(t *testing.T) {
+	isolateConfig(t)
+	var reqLog *requestLog
+	server, reqLog := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/oauth/token":
+			// newMockServer has already read the body into the log.
+			var body api.AccessTokenRequest
+			_ = json.Unmarshal([]byte(reqLog.Body), &body)
+			if body.Code != "auth_code" || body.ClientID != "cid" || body.ClientSecret != "csecret" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"err":"Invalid code","ECODE":"OAUTH_014"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"oauth_access"}`))
+		case "/api/v2/user":
+			if r.Header.Get("Authorization") != "Bearer oauth_access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"err":"Token invalid","ECODE":"OAUTH_025"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"user":{"id":1,"username":"alice"}}`))
+		}
+	})
+	t.Setenv("CLICKUP_BASE_URL", server.URL+"/api")
+
+	// Play the browser: follow the redirect with a code and the same state.
+	oldOpen := openBrowser
+	openBrowser = func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		redirect := u.Query().Get("redirect_uri") + "?code=auth_code&state=" + u.Query().Get("state")
+		go func() {
+			resp, err := http.Get(redirect)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+	defer func() {
+		openBrowser = oldOpen
+		_ = authLoginCmd.Flags().Set("oauth", "false")
+		_ = authLoginCmd.Flags().Set("client-id", "")
+		_ = authLoginCmd.Flags().Set("client-secret", "")
+	}()
+
+	out, err := runCommand(t, server.URL, "auth", "login", "--oauth", "--client-id", "cid", "--client-secret", "csecret")
+	if err != nil {
+		t.Fatalf("oauth login failed: %v", err)
+	}
+	mustContainJSON(t, out, "auth_type", `"auth_type": "oauth"`)
+
+	// Later commands send the stored access token as a Bearer token.
+	config.SetCredentialStore(nil)
+	config.Init()
+	defer viper.Reset()
+	client := newClient(config.GetToken())
+	if client.Token != "oauth_access" || client.TokenType != api.TokenTypeBearer {
+		t.Errorf("expected bearer client, got token %q type %q", client.Token, client.TokenType)
+	}
+}
+
+func TestWaitForOAuthCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "denied", query: "error=access_denied", wantErr: "authorization denied"},
+		{name: "state mismatch", query: "code=c&state=other", wantErr: "state mismatch"},
+		{name: "state missing", query: "code=c", wantErr: "state missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			go func() {
+				resp, err := http.Get("http://" + ln.Addr().String() + "/callback?" + tt.query)
+				if err == nil {
+					resp.Body.Close()
+				}
+			}()
+			_, err = waitForOAuthCode(context.Background(), ln, "expected")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := waitForOAuthCode(ctx, ln, "s"); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout, got %v", err)
+	}
+}
+
+func TestConfigGetSetUnset(t *testing.T) {
+	isolateConfig(t)
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"teams":[{"id":"1","name":"Acme"}]}`))
+	})
+	formatFlag := rootCmd.PersistentFlags().Lookup("format")
+	defer func() {
+		_ = formatFlag.Value.Set("json")
+		formatFlag.Changed = false
+	}()
+
+	for _, args := range [][]string{
+		{"config", "set", "default-list", "900100"},
+		{"config", "set", "timeout", "45s"},
+		{"config", "set", "max_retries", "0"},
+	} {
+		if _, err := runCommand(t, server.URL, args...); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+	}
+
+	out, err := runCommand(t, server.URL, "config", "get", "default_list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `{"key":"default_list","value":"900100","source":"config"}`, out)
+
+	// Invalid values and unknown keys are rejected.
+	for _, args := range [][]string{
+		{"config", "set", "timeout", "soon"},
+		{"config", "set", "format", "yaml"},
+		{"config", "set", "base_url", "api.example.com"},
+		{"config", "set", "max_retries", "-1"},
+		{"config", "set", "colour", "blue"},
+		{"config", "get", "colour"},
+	} {
+		if _, err := runCommand(t, server.URL, args...); err == nil {
+			t.Errorf("%v: expected validation error", args)
+		}
+	}
+
+	out, err = runCommand(t, server.URL, "config", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `["45s"]`, mustQuery(t, out, "settings[?key==timeout].value"))
+	testutil.AssertJSONEqual(t, `["config"]`, mustQuery(t, out, "settings[?key==max_retries].source"))
+
+	// A configured format applies when --format is not given.
+	if _, err := runCommand(t, server.URL, "config", "set", "format", "text"); err != nil {
+		t.Fatal(err)
+	}
+	formatFlag.Changed = false
+	out, err = runCommand(t, server.URL, "workspace", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if json.Valid([]byte(out)) || !strings.Contains(out, "Acme") {
+		t.Errorf("expected text output, got:\n%s", out)
+	}
+
+	if _, err := runCommand(t, server.URL, "config", "unset", "default_list"); err != nil {
+		t.Fatal(err)
+	}
+	out, _ = runCommand(t, server.URL, "--format", "json", "config", "get", "default_list")
+	testutil.AssertJSONEqual(t, `{"key":"default_list","value":"","source":""}`, out)
+
+	out, _ = runCommand(t, server.URL, "config", "path")
+	if !strings.Contains(out, config.ConfigFilePath()) {
+		t.Errorf("expected config path in output, got %s", out)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, loc) // a Wednesday
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, loc) }
+	endOf := func(t time.Time) time.Time { return t.AddDate(0, 0, 1).Add(-time.Millisecond) }
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"1767225600000", time.UnixMilli(1767225600000)},
+		{"2026-03-01", day(2026, 3, 1)},
+		{"2026-03-01T09:30", time.Date(2026, 3, 1, 9, 30, 0, 0, loc)},
+		{"2026-03-01 09:30:15", time.Date(2026, 3, 1, 9, 30, 15, 0, loc)},
+		{"2026-03-01T09:30:00Z", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"2026-03-01T09:30+02:00", time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC)},
+		{"2026-03-01 09:30 UTC", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"now", now},
+		{"Today", day(2026, 10, 14)},
+		{"tomorrow", day(2026, 10, 15)},
+		{"yesterday", day(2026, 10, 13)},
+		{"-7d", now.AddDate(0, 0, -7)},
+		{"+2w", now.AddDate(0, 0, 14)},
+		{"90m", now.Add(90 * time.Minute)},
+		{"-3h", now.Add(-3 * time.Hour)},
+		{"wednesday", day(2026, 10, 14)},
+		{"friday", day(2026, 10, 16)},
+		{"next wednesday", day(2026, 10, 21)},
+		{"next fri", day(2026, 10, 16)},
+		{"last friday", day(2026, 10, 9)},
+		{"last wednesday", day(2026, 10, 7)},
+		{"eod", endOf(day(2026, 10, 14))},
+		{"eow", endOf(day(2026, 10, 18))},
+		{"eom", endOf(day(2026, 10, 31))},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.in, now)
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "someday", "after friday", "2026-13-01", "2026-03-01 Mars/Base"} {
+		if _, err := parseDate(in, now); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestTaskListDateFilters(t *testing.T) {
+	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tasks":[]}`))
+	})
+	defer func() { _ = taskListCmd.Flags().Set("date-updated-gt", "") }()
+
+	if _, err := runCommand(t, server.URL, "task", "list", "--list", "901", "--date-updated-gt", "2026-03-01"); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local).UnixMilli()
+	if !strings.Contains(log.Query, fmt.Sprintf("date_updated_gt=%d", want)) {
+		t.Errorf("expected date_updated_gt=%d in query: %s", want, log.Query)
+	}
+
+	if _, err := runCommand(t, server.URL, "task", "list", "--list", "901", "--date-updated-gt", "someday"); err == nil {
+		t.Error("expected a validation error for an unparseable date")
+	}
+}
+
+func TestNameResolution(t *testing.T) {
+	server, log := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/team/12345678/space":
+			_, _ = w.Write([]byte(`{"spaces":[{"id":"1","name":"Engineering"}]}`))
+		case "/api/v2/space/1/folder":
+			_, _ = w.Write([]byte(`{"folders":[
+				{"id":"10","name":"Backend","lists":[{"id":"100","name":"Sprint 42"}]},
+				{"id":"11","name":"Frontend","lists":[{"id":"110","name":"Sprint 42"}]}]}`))
+		case "/api/v2/space/1/list":
+			_, _ = w.Write([]byte(`{"lists":[]}`))
+		case "/api/v2/list/100/member":
+			_, _ = w.Write([]byte(`{"members":[{"id":7,"username":"alice","email":"alice@corp.com"}]}`))
+		case "/api/v2/list/100":
+			_, _ = w.Write([]byte(`{"id":"100","statuses":[{"status":"to do"},{"status":"In Progress"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"t1","name":"Fix login"}`))
+		}
+	})
+	reset := func() {
+		for _, name := range []string{"list", "status", "assignee"} {
+			f := taskCreateCmd.Flags().Lookup(name)
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				_ = sv.Replace(nil)
+			} else {
+				_ = f.Value.Set("")
+			}
+			f.Changed = false
+		}
+	}
+	reset()
+	defer reset()
+
+	_, err := runCommand(t, server.URL, "task", "create", "--name", "Fix login",
+		"--list", "Backend/Sprint 42", "--assignee", "alice@corp.com", "--status", "in progress")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Path != "/api/v2/list/100/task" {
+		t.Errorf("expected the task to be created in list 100, got %s", log.Path)
+	}
+	testutil.AssertJSONEqual(t, `[7]`, mustQuery(t, log.Body, "assignees"))
+	testutil.AssertJSONEqual(t, `"In Progress"`, mustQuery(t, log.Body, "status"))
+
+	// Two lists are named "Sprint 42": the command fails before creating anything.
+	log.Path = ""
+	if _, err := runCommand(t, server.URL, "task", "create", "--name", "Fix login", "--list", "Sprint 42"); err == nil {
+		t.Error("expected an ambiguity error")
+	}
+	if strings.HasSuffix(log.Path, "/task") {
+		t.Errorf("task created despite the ambiguous list: %s", log.Path)
+	}
+}
+
+func TestCache(t *testing.T) {
+	t.Setenv("CLICKUP_CACHE_DIR", t.TempDir())
+	workspace := spaceListCmd.Flags().Lookup("workspace")
+	_ = workspace.Value.Set("")
+	workspace.Changed = false
+	var listed int
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/team/12345678/space" && r.Method == http.MethodGet:
+			listed++
+			_, _ = w.Write([]byte(`{"spaces":[{"id":"1","name":"Engineering"}]}`))
+		case r.URL.Path == "/api/v2/team/12345678/space":
+			_, _ = w.Write([]byte(`{"id":"2","name":"Design"}`))
+		case r.URL.Path == "/api/v2/team":
+			_, _ = w.Write([]byte(`{"teams":[{"id":"12345678","members":[]}]}`))
+		case strings.HasSuffix(r.URL.Path, "/folder"):
+			_, _ = w.Write([]byte(`{"folders":[{"id":"10","name":"Backend","lists":[{"id":"100","name":"Sprint 42"}]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"lists":[{"id":"101","name":"Inbox"}]}`))
+		}
+	}
+	server, _ := newMockServer(t, handler)
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("no-cache", "false")
+		rootCmd.PersistentFlags().Lookup("no-cache").Changed = false
+		spaceCreateCmd.Flags().Lookup("name").Changed = false
+	}()
+
+	steps := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"space", "list"}, 1},
+		{[]string{"space", "list"}, 1},
+		{[]string{"space", "create", "--name", "Design"}, 1},
+		{[]string{"space", "list"}, 2},
+		{[]string{"space", "list", "--no-cache"}, 3},
+		{[]string{"space", "list"}, 3},
+		{[]string{"cache", "clear"}, 3},
+		{[]string{"space", "list"}, 4},
+	}
+	for _, step := range steps {
+		if _, err := runCommand(t, server.URL, step.args...); err != nil {
+			t.Fatalf("%v: %v", step.args, err)
+		}
+		if listed != step.want {
+			t.Fatalf("after %v: spaces listed %d times, want %d", step.args, listed, step.want)
+		}
+		_ = rootCmd.PersistentFlags().Set("no-cache", "false")
+	}
+
+	out, err := runCommand(t, server.URL, "cache", "refresh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, want := range map[string]string{"spaces": "1", "folders": "1", "lists": "2"} {
+		testutil.AssertJSONEqual(t, want, mustQuery(t, out, key))
+	}
+	if listed != 5 {
+		t.Errorf("expected refresh to refetch spaces, listed %d times", listed)
+	}
+
+	// Another base URL, such as a mock server, has a cache of its own and
+	// leaves this one alone
+	other, _ := newMockServer(t, handler)
+	for _, url := range []string{other.URL, other.URL, server.URL} {
+		if _, err := runCommand(t, url, "space", "list"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if listed != 6 {
+		t.Errorf("expected one fetch for the other base URL, listed %d times", listed)
+	}
+}
+
+func TestTree(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/team":
+			_, _ = w.Write([]byte(`{"teams":[{"id":"12345678","name":"Acme"}]}`))
+		case "/api/v2/team/12345678/space":
+			_, _ = w.Write([]byte(`{"spaces":[{"id":"1","name":"Engineering"}]}`))
+		case "/api/v2/space/1/folder":
+			_, _ = w.Write([]byte(`{"folders":[{"id":"10","name":"Backend","task_count":"3","lists":[{"id":"100","name":"Sprint 42","task_count":3}]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"lists":[]}`))
+		}
+	})
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("format", "json")
+		_ = treeCmd.Flags().Set("tasks", "false")
+	}()
+
+	out, err := runCommand(t, server.URL, "tree", "--tasks", "--format", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `Acme (workspace 12345678, 3 tasks)
+└── Engineering (space 1, 3 tasks)
+    └── Backend/ (folder 10, 3 tasks)
+        └── Sprint 42 (list 100, 3 tasks)
+`
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	if _, err := runCommand(t, server.URL, "tree", "--depth", "4"); err == nil {
+		t.Error("expected an error for --depth 4")
+	}
+}
+
+func TestTaskBulk(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		switch {
+		case strings.Contains(r.URL.Path, "/task/bad"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"err":"Task not found","ECODE":"ITEM_015"}`))
+		case r.URL.Path == "/api/v2/list/100":
+			_, _ = w.Write([]byte(`{"id":"100","statuses":[{"status":"to do"},{"status":"Done"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/task"):
+			_, _ = w.Write([]byte(`{"id":"new1","name":"Created","url":"https://app.clickup.com/t/new1"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"id":"t1","list":{"id":"100"}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	defer func() {
+		for _, name := range []string{"ids", "add-tag", "ids-from", "status"} {
+			f := taskBulkUpdateCmd.Flags().Lookup(name)
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				_ = sv.Replace(nil)
+			} else {
+				_ = f.Value.Set("")
+			}
+			f.Changed = false
+		}
+		_ = taskBulkCreateCmd.Flags().Set("list", "")
+		taskBulkCreateCmd.Flags().Lookup("list").Changed = false
+	}()
+
+	dir := t.TempDir()
+	idsFile := dir + "/ids.txt"
+	_ = os.WriteFile(idsFile, []byte("t1\n\n# skipped\nbad\nt2\n"), 0o600)
+	out, err := runCommand(t, server.URL, "task", "bulk-update", "--ids-from", idsFile,
+		"--status", "done", "--add-tag", "shipped", "--concurrency", "2")
+	if err == nil {
+		t.Error("expected a non-zero exit with a failed task")
+	}
+	testutil.AssertJSONEqual(t, `3`, mustQuery(t, out, "total"))
+	testutil.AssertJSONEqual(t, `1`, mustQuery(t, out, "failed"))
+	testutil.AssertJSONEqual(t, `["t1","bad","t2"]`, mustQuery(t, out, "results.id"))
+	testutil.AssertJSONEqual(t, `"NOT_FOUND"`, mustQuery(t, out, "results[1].error.code"))
+	if requests["POST /api/v2/task/t2/tag/shipped"] != 1 || requests["PUT /api/v2/task/t1"] != 1 {
+		t.Errorf("expected an update and a tag per task, got %v", requests)
+	}
+	if requests["GET /api/v2/list/100"] != 1 {
+		t.Errorf("expected the list's statuses to be fetched once, got %v", requests)
+	}
+
+	tasksFile := dir + "/tasks.jsonl"
+	_ = os.WriteFile(tasksFile, []byte(`{"name":"One","status":"DONE"}`+"\n"+`{"name":"Two","list":"200","tags":["x"]}`+"\n"), 0o600)
+	out, err = runCommand(t, server.URL, "task", "bulk-create", "--from", tasksFile, "--list", "100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `2`, mustQuery(t, out, "succeeded"))
+	testutil.AssertJSONEqual(t, `"new1"`, mustQuery(t, out, "results[0].id"))
+	if requests["POST /api/v2/list/100/task"] != 1 || requests["POST /api/v2/list/200/task"] != 1 {
+		t.Errorf("expected one task per list, got %v", requests)
+	}
+
+	_ = os.WriteFile(tasksFile, []byte(`{"name":"One"}`+"\n"+`{"status":"open"}`+"\n"), 0o600)
+	if _, err := runCommand(t, server.URL, "task", "bulk-create", "--from", tasksFile, "--list", "100"); err == nil {
+		t.Error("expected a validation error for a line without a name")
+	}
+	if requests["POST /api/v2/list/100/task"] != 1 {
+		t.Errorf("expected no task to be created from an invalid file, got %v", requests)
+	}
+
+	emptyFile := dir + "/empty.txt"
+	_ = os.WriteFile(emptyFile, []byte("\n# nothing\n"), 0o600)
+	for _, args := range [][]string{
+		{"task", "bulk-update", "--ids-from", emptyFile, "--status", "done"},
+		{"task", "bulk-create", "--from", emptyFile, "--list", "100"},
+	} {
+		var err error
+		stderr := captureStderr(t, func() { _, err = runCommand(t, server.URL, args...) })
+		if err == nil || !strings.Contains(stderr, "VALIDATION_ERROR") {
+			t.Errorf("%s: expected a validation error for empty input, got %v, %s", args[1], err, stderr)
+		}
+	}
+	if requests["PUT /api/v2/task/t1"] != 1 || requests["POST /api/v2/list/100/task"] != 1 {
+		t.Errorf("expected nothing to be sent for empty input, got %v", requests)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	var sent []string
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("dry-run", "false")
+		rootCmd.PersistentFlags().Lookup("dry-run").Changed = false
+		_ = taskDeleteCmd.Flags().Set("id", "")
+		taskDeleteCmd.Flags().Lookup("id").Changed = false
+		for _, name := range []string{"id", "name"} {
+			_ = spaceUpdateCmd.Flags().Set(name, "")
+			spaceUpdateCmd.Flags().Lookup(name).Changed = false
+		}
+	}()
+
+	out, err := runCommand(t, server.URL, "task", "delete", "--id", "abc123", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `{"dry_run":true,"method":"DELETE","path":"/v2/task/abc123"}`, out)
+
+	out, err = runCommand(t, server.URL, "space", "update", "--id", "790", "--name", "Design", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `{"dry_run":true,"method":"PUT","path":"/v2/space/790","body":{"name":"Design"}}`, out)
+
+	if len(sent) != 0 {
+		t.Errorf("expected nothing to be sent, got %v", sent)
+	}
+}
+
+func TestDeleteImpact(t *testing.T) {
+	t.Setenv("CLICKUP_CACHE_DIR", t.TempDir())
+	var deleted []string
+	folderLists := `{"lists":[{"id":"100","name":"Sprint 42"}]}`
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/v2/folder/10/list" && r.URL.Query().Get("archived") == "true":
+			_, _ = w.Write([]byte(`{"lists":[{"id":"102","name":"Sprint 41","archived":true}]}`))
+		case r.URL.Path == "/api/v2/folder/10/list":
+			_, _ = w.Write([]byte(folderLists))
+		case r.URL.Path == "/api/v2/list/100/task" && r.URL.Query().Get("archived") != "true":
+			if r.URL.Query().Get("subtasks") != "true" || r.URL.Query().Get("include_closed") != "true" {
+				t.Errorf("expected subtasks and closed tasks, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t1","time_spent":3600000},{"id":"s1","parent":"t1"}],"last_page":true}`))
+		case strings.HasSuffix(r.URL.Path, "/task"):
+			_, _ = w.Write([]byte(`{"tasks":[],"last_page":true}`))
+		case strings.HasSuffix(r.URL.Path, "/view"):
+			_, _ = w.Write([]byte(`{"views":[{"id":"view-` + strings.Split(r.URL.Path, "/")[4] + `"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/docs") && r.URL.Query().Get("cursor") == "":
+			_, _ = w.Write([]byte(`{"docs":[{"id":"doc1","parent":{"id":"100","type":6}}],"next_cursor":"c2"}`))
+		case strings.HasSuffix(r.URL.Path, "/docs"):
+			_, _ = w.Write([]byte(`{"docs":[{"id":"doc2","parent":{"id":"101","type":6}}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("dry-run", "false")
+		rootCmd.PersistentFlags().Lookup("dry-run").Changed = false
+		for _, name := range []string{"id", "max-tasks"} {
+			f := folderDeleteCmd.Flags().Lookup(name)
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+		folder := listListCmd.Flags().Lookup("folder")
+		_ = folder.Value.Set("")
+		folder.Changed = false
+	}()
+
+	// The impact is walked past the cache, which still has one list
+	if _, err := runCommand(t, server.URL, "list", "list", "--folder", "10"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	folderLists = `{"lists":[{"id":"100","name":"Sprint 42"},{"id":"101","name":"Sprint 43"}]}`
+
+	out, err := runCommand(t, server.URL, "folder", "delete", "--id", "10", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.AssertJSONEqual(t, `{"dry_run":true,"method":"DELETE","path":"/v2/folder/10","impact":{
+		"type":"folder","id":"10",
+		"folders":{"count":0,"ids":[]},
+		"lists":{"count":3,"ids":["100","101","102"]},
+		"tasks":{"count":1,"ids":["t1"]},
+		"subtasks":{"count":1,"ids":["s1"]},
+		"views":{"count":4,"ids":["view-10","view-100","view-101","view-102"]},
+		"docs":{"count":2,"ids":["doc1","doc2"]},
+		"time_spent_ms":3600000}}`, out)
+	_ = rootCmd.PersistentFlags().Set("dry-run", "false")
+
+	if _, err := runCommand(t, server.URL, "folder", "delete", "--id", "10", "--max-tasks", "1"); err == nil {
+		t.Fatal("expected --max-tasks to refuse the delete")
+	}
+
+	if _, err := runCommand(t, server.URL, "folder", "delete", "--id", "10", "--max-tasks", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "/api/v2/folder/10" {
+		t.Errorf("expected only the last delete to be sent, got %v", deleted)
+	}
+}
+
+func TestTraceFile(t *testing.T) {
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"abc123","name":"Fix login"}`))
+	})
+	path := t.TempDir() + "/trace.har"
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("trace-file", "")
+		rootCmd.PersistentFlags().Lookup("trace-file").Changed = false
+		_ = taskGetCmd.Flags().Set("id", "")
+		taskGetCmd.Flags().Lookup("id").Changed = false
+	}()
+
+	if _, err := runCommand(t, server.URL, "task", "get", "--id", "abc123", "--trace-file", path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trace := string(data)
+	testutil.AssertJSONEqual(t, `["GET"]`, mustQuery(t, trace, "log.entries.request.method"))
+	testutil.AssertJSONEqual(t, `[200]`, mustQuery(t, trace, "log.entries.response.status"))
+	if strings.Contains(trace, "test-token") || !strings.Contains(trace, "[REDACTED]") {
+		t.Errorf("expected the token to be redacted:\n%s", trace)
+	}
+}
+
+func TestClientSettings(t *testing.T) {
+	flags := rootCmd.PersistentFlags()
+	defer func() {
+		for _, f := range clientFlags {
+			_ = flags.Set(f.flag, "")
+			flags.Lookup(f.flag).Changed = false
+		}
+		viper.Reset()
+	}()
+
+	// Other tests reset viper, which drops the bindings made in init.
+	for _, f := range clientFlags {
+		config.BindFlag(f.key, flags.Lookup(f.flag))
+	}
+	viper.Set(config.KeyUserAgent, "acme-bot/2.0")
+	for flag, value := range map[string]string{"base-url": "http://localhost:4010/", "http-timeout": "5s", "max-retries": "0"} {
+		if err := flags.Set(flag, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := newClient("pk_test")
+	if client.BaseURL != "http://localhost:4010" || client.HTTPClient.Timeout != 5*time.Second || client.MaxRetries != 0 {
+		t.Errorf("expected the flags to apply, got base URL %q, timeout %v, %d retries", client.BaseURL, client.HTTPClient.Timeout, client.MaxRetries)
+	}
+	if client.UserAgent != "acme-bot/2.0" {
+		t.Errorf("expected the configured user agent, got %q", client.UserAgent)
+	}
+
+	server, _ := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"spaces":[]}`))
+	})
+	if _, err := runCommand(t, server.URL, "space", "list", "--http-timeout", "soon"); err == nil {
+		t.Error("expected an invalid --http-timeout to fail")
+	}
+}
+
+func TestWebhookListen(t *testing.T) {
+	if _, err := runCommand(t, "", "webhook", "listen"); err == nil {
+		t.Error("expected listen without a secret to fail")
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveWebhooks(ctx, ln, "/hooks", "s3cr3t", webhookEventHandler(&stdout, &stderr, ""))
+	}()
+	post := func(body, secret string) int {
+		req, _ := http.NewRequest("POST", "http://"+ln.Addr().String()+"/hooks", strings.NewReader(body))
+		req.Header.Set("X-Signature", webhook.Sign(secret, []byte(body)))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	body := `{"event":"taskCreated","webhook_id":"w1","task_id":"abc","history_items":[]}`
+	if code := post(body, "s3cr3t"); code != http.StatusOK {
+		t.Errorf("expected 200, got %d", code)
+	}
+	if code := post(body, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", code)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 1 || mustQuery(t, lines[0], "task_id") != `"abc"` {
+		t.Errorf("expected one NDJSON event, got %q", stdout.String())
+	}
+
+	out := t.TempDir() + "/event.json"
+	handle := webhookEventHandler(&stdout, &stderr, `cat > `+out+` && test "$CLICKUP_EVENT" = taskCreated`)
+	e, _ := webhook.Decode([]byte(body))
+	if err := handle(context.Background(), e); err != nil {
+		t.Fatalf("expected the command to succeed: %v (%s)", err, stderr.String())
+	}
+	if data, _ := os.ReadFile(out); mustQuery(t, string(data), "event") != `"taskCreated"` {
+		t.Errorf("expected the event on stdin, got %s", data)
+	}
+	if err := webhookEventHandler(&stdout, &stderr, "exit 3")(context.Background(), e); err == nil {
+		t.Error("expected a failing command to fail the delivery")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/impact"
 	"github.com/blockful/clickup-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -30,15 +31,13 @@ var listListCmd = &cobra.Command{
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		resp, err := client.ListLists(ctx, folderID)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -57,8 +56,7 @@ var listGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -83,7 +81,10 @@ var listCreateCmd = &cobra.Command{
 		req.Content, _ = cmd.Flags().GetString("content")
 		req.Status, _ = cmd.Flags().GetString("status")
 		if cmd.Flags().Changed("due-date") {
-			v, _ := cmd.Flags().GetInt64("due-date")
+			v, err := getDate(cmd, "due-date")
+			if err != nil {
+				return err
+			}
 			req.DueDate = api.Int64Ptr(v)
 		}
 		if cmd.Flags().Changed("priority") {
@@ -91,8 +92,7 @@ var listCreateCmd = &cobra.Command{
 			req.Priority = api.IntPtr(v)
 		}
 		if cmd.Flags().Changed("assignee") {
-			v, _ := cmd.Flags().GetInt("assignee")
-			req.Assignee = api.IntPtr(v)
+			req.Assignee = api.IntPtr(getUserID(cmd, "assignee"))
 		}
 		req.MarkdownContent, _ = cmd.Flags().GetString("markdown-content")
 		if cmd.Flags().Changed("due-date-time") {
@@ -105,15 +105,13 @@ var listCreateCmd = &cobra.Command{
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		resp, err := client.CreateList(ctx, folderID, req)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -139,7 +137,10 @@ var listUpdateCmd = &cobra.Command{
 			req.Status, _ = cmd.Flags().GetString("status")
 		}
 		if cmd.Flags().Changed("due-date") {
-			v, _ := cmd.Flags().GetInt64("due-date")
+			v, err := getDate(cmd, "due-date")
+			if err != nil {
+				return err
+			}
 			req.DueDate = api.Int64Ptr(v)
 		}
 		if cmd.Flags().Changed("priority") {
@@ -147,8 +148,7 @@ var listUpdateCmd = &cobra.Command{
 			req.Priority = api.IntPtr(v)
 		}
 		if cmd.Flags().Changed("assignee") {
-			v, _ := cmd.Flags().GetInt("assignee")
-			req.Assignee = api.IntPtr(v)
+			req.Assignee = api.IntPtr(getUserID(cmd, "assignee"))
 		}
 		if cmd.Flags().Changed("markdown-content") {
 			req.MarkdownContent, _ = cmd.Flags().GetString("markdown-content")
@@ -163,8 +163,7 @@ var listUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -173,33 +172,30 @@ var listDeleteCmd = &cobra.Command{
 	Short: "Delete a list",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
-		ctx := context.Background()
 		id, _ := cmd.Flags().GetString("id")
 		if id == "" {
 			output.PrintError("VALIDATION_ERROR", "--id is required")
 			return &exitError{code: 1}
 		}
-		if err := client.DeleteList(ctx, id); err != nil {
-			return handleError(err)
-		}
-		output.JSON(map[string]string{"message": "list deleted", "id": id})
-		return nil
+		return deleteContainer(cmd, client, impact.TypeList, id, func(ctx context.Context) error {
+			return client.DeleteList(ctx, id)
+		})
 	},
 }
 
 func init() {
-	listListCmd.Flags().String("folder", "", "Folder ID")
-	listListCmd.Flags().String("space", "", "Space ID (for folderless lists)")
+	listListCmd.Flags().String("folder", "", "Folder ID or name")
+	listListCmd.Flags().String("space", "", "Space ID or name (for folderless lists)")
 
 	listGetCmd.Flags().String("id", "", "List ID")
 
-	listCreateCmd.Flags().String("folder", "", "Folder ID")
-	listCreateCmd.Flags().String("space", "", "Space ID (for folderless list)")
+	listCreateCmd.Flags().String("folder", "", "Folder ID or name")
+	listCreateCmd.Flags().String("space", "", "Space ID or name (for folderless list)")
 	listCreateCmd.Flags().String("name", "", "List name")
 	listCreateCmd.Flags().String("content", "", "List description/content")
-	listCreateCmd.Flags().Int64("due-date", 0, "Due date (Unix ms)")
+	listCreateCmd.Flags().String("due-date", "", "Due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	listCreateCmd.Flags().Int("priority", 0, "Priority (1=urgent, 2=high, 3=normal, 4=low)")
-	listCreateCmd.Flags().Int("assignee", 0, "Assignee user ID")
+	listCreateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me)")
 	listCreateCmd.Flags().String("status", "", "List status")
 	listCreateCmd.Flags().String("markdown-content", "", "Markdown content")
 	listCreateCmd.Flags().Bool("due-date-time", false, "Include time in due date")
@@ -207,15 +203,16 @@ func init() {
 	listUpdateCmd.Flags().String("id", "", "List ID")
 	listUpdateCmd.Flags().String("name", "", "List name")
 	listUpdateCmd.Flags().String("content", "", "List description/content")
-	listUpdateCmd.Flags().Int64("due-date", 0, "Due date (Unix ms)")
+	listUpdateCmd.Flags().String("due-date", "", "Due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	listUpdateCmd.Flags().Int("priority", 0, "Priority")
-	listUpdateCmd.Flags().Int("assignee", 0, "Assignee user ID")
+	listUpdateCmd.Flags().String("assignee", "", "Assignee (ID, email, username or me)")
 	listUpdateCmd.Flags().String("status", "", "List status")
 	listUpdateCmd.Flags().Bool("unset-status", false, "Remove list status")
 	listUpdateCmd.Flags().String("markdown-content", "", "Markdown content")
 	listUpdateCmd.Flags().Bool("due-date-time", false, "Include time in due date")
 
 	listDeleteCmd.Flags().String("id", "", "List ID")
+	addDeleteFlags(listDeleteCmd)
 
 	listCmd.AddCommand(listListCmd)
 	listCmd.AddCommand(listGetCmd)
@@ -38,8 +38,7 @@ var memberListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -63,8 +62,7 @@ var groupListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -89,8 +87,7 @@ var groupCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -131,8 +128,7 @@ var groupUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -150,8 +146,7 @@ var groupDeleteCmd = &cobra.Command{
 		if err := client.DeleteGroup(ctx, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -200,8 +195,7 @@ var guestInviteCmd = &cobra.Command{
 		if err := client.InviteGuest(ctx, wid, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -221,8 +215,7 @@ var guestGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -241,8 +234,7 @@ var guestRemoveCmd = &cobra.Command{
 		if err := client.RemoveGuest(ctx, wid, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -287,8 +279,7 @@ var guestEditCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -315,8 +306,7 @@ var guestAddToTaskCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -340,8 +330,7 @@ var guestRemoveFromTaskCmd = &cobra.Command{
 		if err := client.RemoveGuestFromTask(ctx, taskID, guestID, includeShared, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -368,8 +357,7 @@ var guestAddToListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -393,8 +381,7 @@ var guestRemoveFromListCmd = &cobra.Command{
 		if err := client.RemoveGuestFromList(ctx, listID, guestID, includeShared); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -421,8 +408,7 @@ var guestAddToFolderCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -446,8 +432,7 @@ var guestRemoveFromFolderCmd = &cobra.Command{
 		if err := client.RemoveGuestFromFolder(ctx, folderID, guestID, includeShared); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -455,7 +440,7 @@ func init() {
 	rootCmd.AddCommand(memberCmd, groupCmd, guestCmd)
 
 	memberCmd.AddCommand(memberListCmd)
-	memberListCmd.Flags().String("list", "", "List ID")
+	memberListCmd.Flags().String("list", "", "List ID or name")
 	memberListCmd.Flags().String("task", "", "Task ID")
 
 	groupCmd.AddCommand(groupListCmd, groupCreateCmd, groupUpdateCmd, groupDeleteCmd)
@@ -502,19 +487,19 @@ func init() {
 	guestRemoveFromTaskCmd.Flags().Bool("include-shared", false, "Include shared items in response")
 	addTaskScopedFlags(guestRemoveFromTaskCmd)
 
-	guestAddToListCmd.Flags().String("list", "", "List ID (required)")
+	guestAddToListCmd.Flags().String("list", "", "List ID or name (required)")
 	guestAddToListCmd.Flags().Int("guest-id", 0, "Guest ID (required)")
 	guestAddToListCmd.Flags().String("permission-level", "read", "Permission level")
 	guestAddToListCmd.Flags().Bool("include-shared", false, "Include shared items in response")
-	guestRemoveFromListCmd.Flags().String("list", "", "List ID (required)")
+	guestRemoveFromListCmd.Flags().String("list", "", "List ID or name (required)")
 	guestRemoveFromListCmd.Flags().Int("guest-id", 0, "Guest ID (required)")
 	guestRemoveFromListCmd.Flags().Bool("include-shared", false, "Include shared items in response")
 
-	guestAddToFolderCmd.Flags().String("folder", "", "Folder ID (required)")
+	guestAddToFolderCmd.Flags().String("folder", "", "Folder ID or name (required)")
 	guestAddToFolderCmd.Flags().Int("guest-id", 0, "Guest ID (required)")
 	guestAddToFolderCmd.Flags().String("permission-level", "read", "Permission level")
 	guestAddToFolderCmd.Flags().Bool("include-shared", false, "Include shared items in response")
-	guestRemoveFromFolderCmd.Flags().String("folder", "", "Folder ID (required)")
+	guestRemoveFromFolderCmd.Flags().String("folder", "", "Folder ID or name (required)")
 	guestRemoveFromFolderCmd.Flags().Int("guest-id", 0, "Guest ID (required)")
 	guestRemoveFromFolderCmd.Flags().Bool("include-shared", false, "Include shared items in response")
 }
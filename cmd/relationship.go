@@ -42,8 +42,7 @@ var dependencyAddCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -69,8 +68,7 @@ var dependencyRemoveCmd = &cobra.Command{
 		if err := client.DeleteDependency(ctx, taskID, dependsOn, dependencyOf, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -97,8 +95,7 @@ var linkAddCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -119,8 +116,7 @@ var linkRemoveCmd = &cobra.Command{
 		if err := client.DeleteTaskLink(ctx, taskID, linksTo, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
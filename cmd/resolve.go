@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/blockful/clickup-cli/internal/config"
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/blockful/clickup-cli/internal/resolve"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// hierarchyFlags are the flags that take a space, folder or list, in the
+// order they are resolved: a space narrows the search for the others.
+var hierarchyFlags = []string{"space", "folder", "list"}
+
+// userFlags are the flags that take users, one or several.
+var userFlags = []string{"assignee", "assignees-add", "assignees-rem"}
+
+// resolver is the name resolver for the running command; see getResolver.
+var resolver *resolve.Resolver
+
+// getResolver returns the resolver for cmd's workspace, created on first use
+// so commands given only IDs never build a client for it.
+func getResolver(cmd *cobra.Command) *resolve.Resolver {
+	if resolver == nil {
+		wid, _ := cmd.Flags().GetString("workspace")
+		if wid == "" {
+			wid = config.GetWorkspace()
+		}
+		resolver = resolve.New(getClient(), wid)
+	}
+	return resolver
+}
+
+// resolveNameFlags replaces names given to --space, --folder, --list and
+// the assignee flags with the IDs they refer to, so commands read IDs as
+// before. Flags left at their defaults are not touched.
+func resolveNameFlags(cmd *cobra.Command) error {
+	resolver = nil
+	ctx := context.Background()
+	flags := cmd.Flags()
+	changed := func(name string) string {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			return f.Value.String()
+		}
+		return ""
+	}
+
+	for _, name := range hierarchyFlags {
+		ref := changed(name)
+		if ref == "" || resolve.IsID(ref) {
+			continue
+		}
+		var id string
+		var err error
+		switch name {
+		case "space":
+			id, err = getResolver(cmd).Space(ctx, ref)
+		case "folder":
+			id, err = getResolver(cmd).Folder(ctx, ref, changed("space"))
+		case "list":
+			id, err = getResolver(cmd).List(ctx, ref, changed("space"))
+		}
+		if err != nil {
+			return resolveError("--"+name, err)
+		}
+		_ = flags.Set(name, id)
+	}
+
+	listID := changed("list")
+	for _, name := range userFlags {
+		f := flags.Lookup(name)
+		if f == nil || !f.Changed {
+			continue
+		}
+		refs := flagValues(f)
+		ids := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			// checklist-item update takes "null" to clear the assignee.
+			if resolve.IsID(ref) || ref == "null" {
+				ids = append(ids, ref)
+				continue
+			}
+			id, err := getResolver(cmd).User(ctx, ref, listID)
+			if err != nil {
+				return resolveError("--"+name, err)
+			}
+			ids = append(ids, strconv.Itoa(id))
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(ids)
+		} else {
+			_ = f.Value.Set(strings.Join(ids, ","))
+		}
+	}
+	return nil
+}
+
+// flagValues returns the values of a slice flag, or the comma-separated
+// values of a string flag.
+func flagValues(f *pflag.Flag) []string {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		return sv.GetSlice()
+	}
+	var values []string
+	for _, v := range strings.Split(f.Value.String(), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// resolveStatuses returns refs spelled as the statuses of listID, so status
+// names need not match case.
+func resolveStatuses(cmd *cobra.Command, refs []string, listID string) ([]string, error) {
+	if len(refs) == 0 || listID == "" {
+		return refs, nil
+	}
+	statuses, err := getResolver(cmd).Statuses(context.Background(), refs, listID)
+	if err != nil {
+		return nil, resolveError("--status", err)
+	}
+	return statuses, nil
+}
+
+// resolveError prints a failed lookup, listing the candidates of an
+// ambiguous or unknown name.
+func resolveError(flag string, err error) error {
+	var re *resolve.Error
+	if !errors.As(err, &re) {
+		return handleError(err)
+	}
+	var candidates interface{}
+	if len(re.Candidates) > 0 {
+		candidates = re.Candidates
+	}
+	output.PrintErrorCandidates(re.Code(), flag+": "+re.Error(), candidates)
+	return &exitError{code: 1}
+}
+
+// getUserIDs returns the user IDs in a slice flag, after resolveNameFlags.
+func getUserIDs(cmd *cobra.Command, name string) []int {
+	refs, _ := cmd.Flags().GetStringSlice(name)
+	var ids []int
+	for _, ref := range refs {
+		if id, err := strconv.Atoi(ref); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// getUserID returns the user ID in a flag, after resolveNameFlags.
+func getUserID(cmd *cobra.Command, name string) int {
+	ref, _ := cmd.Flags().GetString(name)
+	id, _ := strconv.Atoi(ref)
+	return id
+}
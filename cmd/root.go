@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/cache"
 	"github.com/blockful/clickup-cli/internal/config"
+	"github.com/blockful/clickup-cli/internal/impact"
 	"github.com/blockful/clickup-cli/internal/output"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var rootCmd = &cobra.Command{
@@ -14,36 +21,170 @@ var rootCmd = &cobra.Command{
 	Long:          "A command-line interface for ClickUp, designed for AI agent workflows. All output is JSON by default.",
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		profile, _ := cmd.Flags().GetString("profile")
+		config.SetProfile(profile)
+		if p := config.ActiveProfile(); p != "" && !config.HasProfile(p) {
+			output.PrintError("VALIDATION_ERROR", fmt.Sprintf("profile %q does not exist; see 'clickup config profile list'", p))
+			return &exitError{code: 1}
+		}
+		for _, f := range clientFlags {
+			flag := cmd.Flags().Lookup(f.flag)
+			if !flag.Changed {
+				continue
+			}
+			k, _ := findConfigKey(f.key)
+			if err := k.validate(flag.Value.String()); err != nil {
+				output.PrintError("VALIDATION_ERROR", fmt.Sprintf("--%s: %v", f.flag, err))
+				return &exitError{code: 1}
+			}
+		}
+		format, _ := cmd.Flags().GetString("format")
+		if !cmd.Flags().Changed("format") {
+			if f := config.Get(config.KeyFormat); f != "" {
+				format = f
+			}
+		}
+		if err := output.SetFormat(format); err != nil {
+			output.PrintError("VALIDATION_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		query, _ := cmd.Flags().GetString("query")
+		if err := output.SetQuery(query); err != nil {
+			output.PrintError("VALIDATION_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		fields, _ := cmd.Flags().GetString("fields")
+		output.SetFields(strings.Split(fields, ","))
+		columns, _ := cmd.Flags().GetString("columns")
+		output.SetColumns(strings.Split(columns, ","))
+		return resolveNameFlags(cmd)
+	},
 }
 
 // clientFactory can be overridden in tests to inject a mock client.
 var clientFactory func() api.ClientInterface
 
+// clientFlags are the global flags that override API client settings, and
+// the config keys they override.
+var clientFlags = []struct{ flag, key string }{
+	{"base-url", config.KeyBaseURL},
+	{"http-timeout", config.KeyTimeout},
+	{"max-retries", config.KeyMaxRetries},
+	{"user-agent", config.KeyUserAgent},
+}
+
+// dryRun is bound to the global --dry-run flag.
+var dryRun bool
+
+// verbose and traceFile are bound to the global --verbose and --trace-file
+// flags; traceLog is the logger for traceFile, shared by every client of
+// the command.
+var (
+	verbose   bool
+	traceFile string
+	traceLog  *api.HARLogger
+)
+
 func Execute() error {
 	return rootCmd.Execute()
 }
 
 func init() {
 	cobra.OnInitialize(config.Init)
+	config.SetPassphrasePrompt(promptPassphrase)
 
 	rootCmd.PersistentFlags().String("token", "", "ClickUp API token (overrides config)")
 	rootCmd.PersistentFlags().String("workspace", "", "Default workspace ID (overrides config)")
-	rootCmd.PersistentFlags().String("format", "json", "Output format: json or text")
-	rootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().String("profile", "", "Config profile to use (overrides CLICKUP_PROFILE and the current profile)")
+	rootCmd.PersistentFlags().String("format", "json", "Output format: json, text, ndjson, csv or tsv")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Log HTTP requests and responses to stderr, with credentials redacted")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "Record HTTP requests and responses to a HAR file, with credentials redacted")
+	rootCmd.PersistentFlags().String("fields", "", "Comma-separated paths to keep in the output (e.g. id,name,status.status)")
+	rootCmd.PersistentFlags().String("columns", "", "Comma-separated columns to write with --format csv or tsv")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the request that would change something instead of sending it")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Fetch spaces, folders, lists and members from the API instead of the local cache")
+	rootCmd.PersistentFlags().String("query", "", "Path expression to select or filter the output (e.g. 'tasks[?status.status==open].id')")
 
-	_ = viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token"))
-	_ = viper.BindPFlag("workspace", rootCmd.PersistentFlags().Lookup("workspace"))
+	rootCmd.PersistentFlags().String("base-url", "", "API base URL, such as a mock server or proxy (overrides config)")
+	rootCmd.PersistentFlags().String("http-timeout", "", "HTTP request timeout, e.g. 30s (overrides config)")
+	rootCmd.PersistentFlags().String("max-retries", "", "Retries for 429 and 5xx responses (overrides config)")
+	rootCmd.PersistentFlags().String("user-agent", "", "User-Agent header (overrides config)")
+
+	config.BindFlag(config.KeyToken, rootCmd.PersistentFlags().Lookup("token"))
+	config.BindFlag(config.KeyWorkspace, rootCmd.PersistentFlags().Lookup("workspace"))
+	for _, f := range clientFlags {
+		config.BindFlag(f.key, rootCmd.PersistentFlags().Lookup(f.flag))
+	}
 }
 
 func getClient() api.ClientInterface {
+	var client api.ClientInterface
 	if clientFactory != nil {
-		return clientFactory()
+		client = clientFactory()
+	} else {
+		token := config.GetToken()
+		if token == "" {
+			output.PrintErrorAndExit("AUTH_REQUIRED", "No API token configured. Run 'clickup auth login' first.", 2)
+		}
+		client = newClient(token)
 	}
-	token := config.GetToken()
-	if token == "" {
-		output.PrintErrorAndExit("AUTH_REQUIRED", "No API token configured. Run 'clickup auth login' first.", 2)
+	if c, ok := client.(*api.Client); ok {
+		c.DryRun = dryRun
+		c.Logger = httpLogger()
 	}
-	return api.NewClient(token)
+	return withCache(client)
+}
+
+// httpLogger returns the logger for --verbose and --trace-file, or nil if
+// neither is set.
+func httpLogger() api.Logger {
+	var loggers api.Loggers
+	if verbose {
+		loggers = append(loggers, api.NewTextLogger(os.Stderr))
+	}
+	if traceFile != "" {
+		if traceLog == nil || traceLog.Path != traceFile {
+			traceLog = api.NewHARLogger(traceFile, version)
+		}
+		loggers = append(loggers, traceLog)
+	}
+	if len(loggers) == 0 {
+		return nil
+	}
+	return loggers
+}
+
+// newClient creates an API client for token using the configured base URL.
+// OAuth access tokens are sent as Bearer tokens; personal tokens (pk_...)
+// never are, so CLICKUP_TOKEN can still override an OAuth login.
+func newClient(token string) *api.Client {
+	opts := []api.Option{api.WithUserAgent(userAgent()), api.WithLogger(httpLogger())}
+	if baseURL := config.GetBaseURL(); baseURL != "" {
+		opts = append(opts, api.WithBaseURL(baseURL))
+	}
+	if n, ok := config.GetMaxRetries(); ok {
+		opts = append(opts, api.WithRetries(n))
+	}
+	if d := config.GetRetryBaseWait(); d > 0 {
+		opts = append(opts, api.WithRetryBaseWait(d))
+	}
+	if d := config.GetTimeout(); d > 0 {
+		opts = append(opts, api.WithTimeout(d))
+	}
+	client := api.NewClient(token, opts...)
+	if config.GetAuthType() == config.AuthTypeOAuth && !strings.HasPrefix(token, "pk_") {
+		client.TokenType = api.TokenTypeBearer
+	}
+	return client
+}
+
+// userAgent returns the configured User-Agent, or one naming this version.
+func userAgent() string {
+	if ua := config.GetUserAgent(); ua != "" {
+		return ua
+	}
+	return api.DefaultUserAgent + "/" + version
 }
 
 func getWorkspaceID(cmd *cobra.Command) string {
@@ -52,12 +193,38 @@ func getWorkspaceID(cmd *cobra.Command) string {
 		id = config.GetWorkspace()
 	}
 	if id == "" {
-		output.PrintErrorAndExit("WORKSPACE_REQUIRED", "Workspace ID required. Use --workspace flag or set default with config.", 1)
+		output.PrintErrorAndExit("WORKSPACE_REQUIRED", "Workspace ID required. Use --workspace flag or set a default with 'clickup config set workspace <id>'.", 1)
+	}
+	return id
+}
+
+// getSpaceID returns --space, falling back to the configured default space.
+func getSpaceID(cmd *cobra.Command) string {
+	id, _ := cmd.Flags().GetString("space")
+	if id == "" {
+		id = config.GetDefaultSpace()
+	}
+	return id
+}
+
+// getListID returns --list, falling back to the configured default list.
+func getListID(cmd *cobra.Command) string {
+	id, _ := cmd.Flags().GetString("list")
+	if id == "" {
+		id = config.GetDefaultList()
 	}
 	return id
 }
 
 func handleError(err error) error {
+	var dryRunErr *api.DryRunError
+	if errors.As(err, &dryRunErr) {
+		// The request a dry run stopped at is the command's output.
+		return output.Print(struct {
+			DryRun bool `json:"dry_run"`
+			api.DryRunRequest
+		}{true, dryRunErr.Request})
+	}
 	if clientErr, ok := err.(*api.ClientError); ok {
 		output.PrintError(clientErr.Code, clientErr.Message)
 		if clientErr.Code == "UNAUTHORIZED" {
@@ -92,3 +259,94 @@ func addTaskScopedFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("custom-task-ids", false, "Use custom task IDs (requires --team-id)")
 	cmd.Flags().String("team-id", "", "Team ID (required when --custom-task-ids is set)")
 }
+
+// addPaginationFlags adds --all and --max-pages flags to a paginated list command.
+func addPaginationFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Fetch all pages (starting at --page) and merge the results")
+	cmd.Flags().Int("max-pages", 0, "Maximum number of pages to fetch with --all (0 = no limit)")
+}
+
+// printPages walks the pages of fetch. In a streaming format such as ndjson
+// each page is printed as soon as it arrives; otherwise the merged items are
+// passed to wrap and printed once the walk finishes.
+func printPages[T any](ctx context.Context, fetch api.PageFunc[T], opts api.PaginateOptions, wrap func(items []T, complete bool) interface{}) error {
+	if output.Streaming() {
+		if _, err := api.Paginate(ctx, fetch, opts, func(items []T) error {
+			return output.PrintRecords(items)
+		}); err != nil {
+			return handleError(err)
+		}
+		return nil
+	}
+	items, complete, err := api.CollectPages(ctx, fetch, opts)
+	if err != nil {
+		return handleError(err)
+	}
+	return output.Print(wrap(items, complete))
+}
+
+// deleteContainer deletes the space, folder or list id with del and reports
+// it. --max-tasks refuses the delete when more tasks and subtasks than that
+// would go with it; with --dry-run, what the delete would remove is printed
+// along with the request.
+func deleteContainer(cmd *cobra.Command, client api.ClientInterface, typ, id string, del func(ctx context.Context) error) error {
+	ctx := context.Background()
+	maxTasks, _ := cmd.Flags().GetInt("max-tasks")
+	if maxTasks < 0 {
+		output.PrintError("VALIDATION_ERROR", "--max-tasks must not be negative")
+		return &exitError{code: 1}
+	}
+	var report *impact.Report
+	if dryRun || maxTasks > 0 {
+		// A stale cached hierarchy would undercount what the delete removes
+		walker := client
+		if c, ok := client.(*cache.Client); ok {
+			fresh := *c
+			fresh.Refresh = true
+			walker = &fresh
+		}
+		var err error
+		if report, err = impact.Walk(ctx, walker, getWorkspaceID(cmd), typ, id); err != nil {
+			return handleError(err)
+		}
+		if maxTasks > 0 && report.TaskCount() > maxTasks {
+			output.PrintError("TOO_MANY_TASKS", fmt.Sprintf("deleting %s %s would remove %d tasks and subtasks, more than --max-tasks %d", typ, id, report.TaskCount(), maxTasks))
+			return &exitError{code: 1}
+		}
+	}
+	err := del(ctx)
+	var dryRunErr *api.DryRunError
+	if errors.As(err, &dryRunErr) {
+		return output.Print(struct {
+			DryRun bool `json:"dry_run"`
+			api.DryRunRequest
+			Impact *impact.Report `json:"impact"`
+		}{true, dryRunErr.Request, report})
+	}
+	if err != nil {
+		return handleError(err)
+	}
+	return output.Print(map[string]string{"message": typ + " deleted", "id": id})
+}
+
+// addDeleteFlags adds --max-tasks to a space, folder or list delete command.
+func addDeleteFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("max-tasks", 0, "Refuse to delete if more tasks and subtasks than this would be removed (0 = no limit)")
+}
+
+// getPaginateOpts returns pagination options if --all is set, or nil for a
+// single page. A negative --max-pages fails with a VALIDATION_ERROR.
+func getPaginateOpts(cmd *cobra.Command) (*api.PaginateOptions, error) {
+	maxPages, _ := cmd.Flags().GetInt("max-pages")
+	if maxPages < 0 {
+		output.PrintError("VALIDATION_ERROR", "--max-pages must not be negative")
+		return nil, &exitError{code: 1}
+	}
+	all, _ := cmd.Flags().GetBool("all")
+	if !all {
+		return nil, nil
+	}
+	opts := &api.PaginateOptions{MaxPages: maxPages}
+	opts.StartPage, _ = cmd.Flags().GetInt("page")
+	return opts, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/impact"
 	"github.com/blockful/clickup-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -25,8 +26,7 @@ var spaceListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -45,8 +45,7 @@ var spaceGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -77,8 +76,7 @@ var spaceCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -122,8 +120,7 @@ var spaceUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -132,17 +129,14 @@ var spaceDeleteCmd = &cobra.Command{
 	Short: "Delete a space",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
-		ctx := context.Background()
 		id, _ := cmd.Flags().GetString("id")
 		if id == "" {
 			output.PrintError("VALIDATION_ERROR", "--id is required")
 			return &exitError{code: 1}
 		}
-		if err := client.DeleteSpace(ctx, id); err != nil {
-			return handleError(err)
-		}
-		output.JSON(map[string]string{"message": "space deleted", "id": id})
-		return nil
+		return deleteContainer(cmd, client, impact.TypeSpace, id, func(ctx context.Context) error {
+			return client.DeleteSpace(ctx, id)
+		})
 	},
 }
 
@@ -164,6 +158,7 @@ func init() {
 	spaceUpdateCmd.Flags().String("features", "", "Space features (JSON object)")
 
 	spaceDeleteCmd.Flags().String("id", "", "Space ID")
+	addDeleteFlags(spaceDeleteCmd)
 
 	spaceCmd.AddCommand(spaceListCmd)
 	spaceCmd.AddCommand(spaceGetCmd)
@@ -19,17 +19,16 @@ var tagListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		spaceID, _ := cmd.Flags().GetString("space")
+		spaceID := getSpaceID(cmd)
 		if spaceID == "" {
-			output.PrintError("VALIDATION_ERROR", "--space is required")
+			output.PrintError("VALIDATION_ERROR", "--space is required (or set a default with 'clickup config set default_space <id>')")
 			return &exitError{code: 1}
 		}
 		resp, err := client.GetSpaceTags(ctx, spaceID)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -39,7 +38,7 @@ var tagCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		spaceID, _ := cmd.Flags().GetString("space")
+		spaceID := getSpaceID(cmd)
 		name, _ := cmd.Flags().GetString("name")
 		fg, _ := cmd.Flags().GetString("fg")
 		bg, _ := cmd.Flags().GetString("bg")
@@ -53,8 +52,7 @@ var tagCreateCmd = &cobra.Command{
 		if err := client.CreateSpaceTag(ctx, spaceID, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -64,7 +62,7 @@ var tagUpdateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		spaceID, _ := cmd.Flags().GetString("space")
+		spaceID := getSpaceID(cmd)
 		tagName, _ := cmd.Flags().GetString("name")
 		newName, _ := cmd.Flags().GetString("new-name")
 		fg, _ := cmd.Flags().GetString("fg")
@@ -85,8 +83,7 @@ var tagUpdateCmd = &cobra.Command{
 		if err := client.UpdateSpaceTag(ctx, spaceID, tagName, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -96,7 +93,7 @@ var tagDeleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		spaceID, _ := cmd.Flags().GetString("space")
+		spaceID := getSpaceID(cmd)
 		tagName, _ := cmd.Flags().GetString("name")
 
 		if spaceID == "" || tagName == "" {
@@ -107,8 +104,7 @@ var tagDeleteCmd = &cobra.Command{
 		if err := client.DeleteSpaceTag(ctx, spaceID, tagName); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -129,8 +125,7 @@ var tagAddCmd = &cobra.Command{
 		if err := client.AddTagToTask(ctx, taskID, tagName, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -151,8 +146,7 @@ var tagRemoveCmd = &cobra.Command{
 		if err := client.RemoveTagFromTask(ctx, taskID, tagName, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -160,20 +154,20 @@ func init() {
 	rootCmd.AddCommand(tagCmd)
 	tagCmd.AddCommand(tagListCmd, tagCreateCmd, tagUpdateCmd, tagDeleteCmd, tagAddCmd, tagRemoveCmd)
 
-	tagListCmd.Flags().String("space", "", "Space ID (required)")
+	tagListCmd.Flags().String("space", "", "Space ID or name (required unless default_space is set)")
 
-	tagCreateCmd.Flags().String("space", "", "Space ID (required)")
+	tagCreateCmd.Flags().String("space", "", "Space ID or name (required unless default_space is set)")
 	tagCreateCmd.Flags().String("name", "", "Tag name (required)")
 	tagCreateCmd.Flags().String("fg", "#000000", "Foreground color")
 	tagCreateCmd.Flags().String("bg", "#000000", "Background color")
 
-	tagUpdateCmd.Flags().String("space", "", "Space ID (required)")
+	tagUpdateCmd.Flags().String("space", "", "Space ID or name (required unless default_space is set)")
 	tagUpdateCmd.Flags().String("name", "", "Current tag name (required)")
 	tagUpdateCmd.Flags().String("new-name", "", "New tag name")
 	tagUpdateCmd.Flags().String("fg", "", "Foreground color")
 	tagUpdateCmd.Flags().String("bg", "", "Background color")
 
-	tagDeleteCmd.Flags().String("space", "", "Space ID (required)")
+	tagDeleteCmd.Flags().String("space", "", "Space ID or name (required unless default_space is set)")
 	tagDeleteCmd.Flags().String("name", "", "Tag name (required)")
 
 	tagAddCmd.Flags().String("task", "", "Task ID (required)")
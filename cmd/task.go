@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/blockful/clickup-cli/internal/api"
 	"github.com/blockful/clickup-cli/internal/output"
@@ -19,14 +22,19 @@ var taskListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		listID, _ := cmd.Flags().GetString("list")
+		listID := getListID(cmd)
 		if listID == "" {
-			output.PrintError("VALIDATION_ERROR", "--list is required")
+			output.PrintError("VALIDATION_ERROR", "--list is required (or set a default with 'clickup config set default_list <id>')")
 			return &exitError{code: 1}
 		}
 
 		opts := &api.ListTasksOptions{}
-		opts.Statuses, _ = cmd.Flags().GetStringSlice("status")
+		statuses, _ := cmd.Flags().GetStringSlice("status")
+		statuses, err := resolveStatuses(cmd, statuses, listID)
+		if err != nil {
+			return err
+		}
+		opts.Statuses = statuses
 		opts.Assignees, _ = cmd.Flags().GetStringSlice("assignee")
 		opts.Tags, _ = cmd.Flags().GetStringSlice("tag")
 		opts.Watchers, _ = cmd.Flags().GetStringSlice("watchers")
@@ -38,23 +46,36 @@ var taskListCmd = &cobra.Command{
 		opts.Archived, _ = cmd.Flags().GetBool("archived")
 		opts.IncludeMarkdown, _ = cmd.Flags().GetBool("include-markdown")
 		opts.IncludeTiml, _ = cmd.Flags().GetBool("include-timl")
-		opts.DueDateGt, _ = cmd.Flags().GetInt64("due-date-gt")
-		opts.DueDateLt, _ = cmd.Flags().GetInt64("due-date-lt")
-		opts.DateCreatedGt, _ = cmd.Flags().GetInt64("date-created-gt")
-		opts.DateCreatedLt, _ = cmd.Flags().GetInt64("date-created-lt")
-		opts.DateUpdatedGt, _ = cmd.Flags().GetInt64("date-updated-gt")
-		opts.DateUpdatedLt, _ = cmd.Flags().GetInt64("date-updated-lt")
-		opts.DateDoneGt, _ = cmd.Flags().GetInt64("date-done-gt")
-		opts.DateDoneLt, _ = cmd.Flags().GetInt64("date-done-lt")
+		if err := getDates(cmd, map[string]*int64{
+			"due-date-gt":     &opts.DueDateGt,
+			"due-date-lt":     &opts.DueDateLt,
+			"date-created-gt": &opts.DateCreatedGt,
+			"date-created-lt": &opts.DateCreatedLt,
+			"date-updated-gt": &opts.DateUpdatedGt,
+			"date-updated-lt": &opts.DateUpdatedLt,
+			"date-done-gt":    &opts.DateDoneGt,
+			"date-done-lt":    &opts.DateDoneLt,
+		}); err != nil {
+			return err
+		}
 		opts.CustomFields, _ = cmd.Flags().GetString("custom-fields")
 		opts.CustomItems, _ = cmd.Flags().GetIntSlice("custom-items")
 
+		popts, err := getPaginateOpts(cmd)
+		if err != nil {
+			return err
+		}
+		if popts != nil {
+			return printPages(ctx, api.ListTasksPages(client, listID, opts), *popts, func(tasks []api.Task, complete bool) interface{} {
+				return &api.TasksResponse{Tasks: tasks, LastPage: complete}
+			})
+		}
+
 		resp, err := client.ListTasks(ctx, listID, opts)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -79,8 +100,7 @@ var taskGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -90,9 +110,9 @@ var taskCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := getClient()
 		ctx := context.Background()
-		listID, _ := cmd.Flags().GetString("list")
+		listID := getListID(cmd)
 		if listID == "" {
-			output.PrintError("VALIDATION_ERROR", "--list is required")
+			output.PrintError("VALIDATION_ERROR", "--list is required (or set a default with 'clickup config set default_list <id>')")
 			return &exitError{code: 1}
 		}
 		name, _ := cmd.Flags().GetString("name")
@@ -107,7 +127,13 @@ var taskCreateCmd = &cobra.Command{
 		if req.MarkdownDescription == "" {
 			req.MarkdownDescription, _ = cmd.Flags().GetString("markdown-content")
 		}
-		req.Status, _ = cmd.Flags().GetString("status")
+		if status, _ := cmd.Flags().GetString("status"); status != "" {
+			statuses, err := resolveStatuses(cmd, []string{status}, listID)
+			if err != nil {
+				return err
+			}
+			req.Status = statuses[0]
+		}
 		req.Parent, _ = cmd.Flags().GetString("parent")
 		req.LinksTo, _ = cmd.Flags().GetString("links-to")
 
@@ -116,7 +142,7 @@ var taskCreateCmd = &cobra.Command{
 			req.Tags = tags
 		}
 
-		assignees, _ := cmd.Flags().GetIntSlice("assignee")
+		assignees := getUserIDs(cmd, "assignee")
 		if len(assignees) > 0 {
 			req.Assignees = assignees
 		}
@@ -145,7 +171,10 @@ var taskCreateCmd = &cobra.Command{
 		}
 
 		if cmd.Flags().Changed("due-date") {
-			v, _ := cmd.Flags().GetInt64("due-date")
+			v, err := getDate(cmd, "due-date")
+			if err != nil {
+				return err
+			}
 			req.DueDate = api.Int64Ptr(v)
 		}
 		if cmd.Flags().Changed("due-date-time") {
@@ -153,7 +182,10 @@ var taskCreateCmd = &cobra.Command{
 			req.DueDateTime = api.BoolPtr(v)
 		}
 		if cmd.Flags().Changed("start-date") {
-			v, _ := cmd.Flags().GetInt64("start-date")
+			v, err := getDate(cmd, "start-date")
+			if err != nil {
+				return err
+			}
 			req.StartDate = api.Int64Ptr(v)
 		}
 		if cmd.Flags().Changed("start-date-time") {
@@ -175,13 +207,25 @@ var taskCreateCmd = &cobra.Command{
 			}
 			req.CustomFields = fields
 		}
+		fieldValues, err := getFieldValues(cmd, client, listID)
+		if err != nil {
+			return err
+		}
+		for _, fv := range fieldValues {
+			for _, cf := range req.CustomFields {
+				if cf.ID == fv.ID {
+					output.PrintError("VALIDATION_ERROR", fmt.Sprintf("custom field %s is given by both --custom-fields and --field", fv.ID))
+					return &exitError{code: 1}
+				}
+			}
+		}
+		req.CustomFields = append(req.CustomFields, fieldValues...)
 
 		resp, err := client.CreateTask(ctx, listID, req)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -197,6 +241,21 @@ var taskUpdateCmd = &cobra.Command{
 			return &exitError{code: 1}
 		}
 
+		getOpts := api.GetTaskOptions{}
+		getOpts.CustomTaskIDs, _ = cmd.Flags().GetBool("custom-task-ids")
+		getOpts.TeamID, _ = cmd.Flags().GetString("team-id")
+
+		// The task is fetched at most once, for its list, when a flag needs it
+		var task *api.Task
+		getTask := func() (*api.Task, error) {
+			if task != nil {
+				return task, nil
+			}
+			var err error
+			task, err = client.GetTask(ctx, id, getOpts)
+			return task, err
+		}
+
 		req := &api.UpdateTaskRequest{}
 		if cmd.Flags().Changed("name") {
 			v, _ := cmd.Flags().GetString("name")
@@ -208,6 +267,17 @@ var taskUpdateCmd = &cobra.Command{
 		}
 		if cmd.Flags().Changed("status") {
 			v, _ := cmd.Flags().GetString("status")
+			if v != "" {
+				task, err := getTask()
+				if err != nil {
+					return handleError(err)
+				}
+				statuses, err := resolveStatuses(cmd, []string{v}, task.List.ID)
+				if err != nil {
+					return err
+				}
+				v = statuses[0]
+			}
 			req.Status = api.StringPtr(v)
 		}
 		if cmd.Flags().Changed("priority") {
@@ -215,7 +285,10 @@ var taskUpdateCmd = &cobra.Command{
 			req.Priority = api.IntPtr(v)
 		}
 		if cmd.Flags().Changed("due-date") {
-			v, _ := cmd.Flags().GetInt64("due-date")
+			v, err := getDate(cmd, "due-date")
+			if err != nil {
+				return err
+			}
 			req.DueDate = api.Int64Ptr(v)
 		}
 		if cmd.Flags().Changed("due-date-time") {
@@ -223,7 +296,10 @@ var taskUpdateCmd = &cobra.Command{
 			req.DueDateTime = api.BoolPtr(v)
 		}
 		if cmd.Flags().Changed("start-date") {
-			v, _ := cmd.Flags().GetInt64("start-date")
+			v, err := getDate(cmd, "start-date")
+			if err != nil {
+				return err
+			}
 			req.StartDate = api.Int64Ptr(v)
 		}
 		if cmd.Flags().Changed("start-date-time") {
@@ -256,8 +332,8 @@ var taskUpdateCmd = &cobra.Command{
 			req.CustomItemID = api.IntPtr(v)
 		}
 
-		addAssignees, _ := cmd.Flags().GetIntSlice("assignees-add")
-		remAssignees, _ := cmd.Flags().GetIntSlice("assignees-rem")
+		addAssignees := getUserIDs(cmd, "assignees-add")
+		remAssignees := getUserIDs(cmd, "assignees-rem")
 		if len(addAssignees) > 0 || len(remAssignees) > 0 {
 			req.Assignees = &api.UpdateTaskAssignees{Add: addAssignees, Rem: remAssignees}
 		}
@@ -268,19 +344,101 @@ var taskUpdateCmd = &cobra.Command{
 			req.GroupAssignees = &api.UpdateTaskGroupAssignees{Add: addGroups, Rem: remGroups}
 		}
 
+		// Every --field value is validated before anything is changed
+		var fieldValues []api.CustomFieldValue
+		if cmd.Flags().Changed("field") {
+			task, err := getTask()
+			if err != nil {
+				return handleError(err)
+			}
+			if fieldValues, err = getFieldValues(cmd, client, task.List.ID); err != nil {
+				return err
+			}
+		}
+
 		updateOpts := api.UpdateTaskOptions{}
 		updateOpts.CustomTaskIDs, _ = cmd.Flags().GetBool("custom-task-ids")
 		updateOpts.TeamID, _ = cmd.Flags().GetString("team-id")
 
-		resp, err := client.UpdateTask(ctx, id, req, updateOpts)
-		if err != nil {
-			return handleError(err)
+		// With only --field given there is nothing for the update endpoint
+		var resp *api.Task
+		var updateRequest *api.DryRunRequest
+		updated := false
+		if len(fieldValues) == 0 || *req != (api.UpdateTaskRequest{}) {
+			var err error
+			resp, err = client.UpdateTask(ctx, id, req, updateOpts)
+			var dryRunErr *api.DryRunError
+			switch {
+			case errors.As(err, &dryRunErr):
+				updateRequest = &dryRunErr.Request
+			case err != nil:
+				return handleError(err)
+			default:
+				updated = true
+			}
 		}
-		output.JSON(resp)
-		return nil
+
+		// The update endpoint does not take custom fields, so each is set
+		// on its own once the update is accepted
+		var fieldRequests []api.DryRunRequest
+		var applied []string
+		for _, fv := range fieldValues {
+			fieldReq := &api.SetCustomFieldRequest{Value: fv.Value, ValueOptions: fv.ValueOptions}
+			err := client.SetCustomFieldValue(ctx, id, fv.ID, fieldReq, getTaskScopedOpts(cmd))
+			var dryRunErr *api.DryRunError
+			if errors.As(err, &dryRunErr) {
+				fieldRequests = append(fieldRequests, dryRunErr.Request)
+				continue
+			}
+			if err != nil {
+				return fieldError(err, fv.ID, updated, applied)
+			}
+			applied = append(applied, fv.ID)
+		}
+		if updateRequest != nil || len(fieldRequests) > 0 {
+			return output.Print(struct {
+				DryRun bool `json:"dry_run"`
+				*api.DryRunRequest
+				Fields []api.DryRunRequest `json:"fields,omitempty"`
+			}{true, updateRequest, fieldRequests})
+		}
+		if len(fieldValues) > 0 {
+			// Refetched so that the printed task shows the new values
+			var err error
+			if resp, err = client.GetTask(ctx, id, getOpts); err != nil {
+				return handleError(err)
+			}
+		}
+		return output.Print(resp)
 	},
 }
 
+// fieldError reports a custom field that could not be set, saying what of
+// task update had already been applied: the update itself when updated, and
+// the fields in applied.
+func fieldError(err error, fieldID string, updated bool, applied []string) error {
+	var done []string
+	if updated {
+		done = append(done, "the task update")
+	}
+	if len(applied) > 0 {
+		done = append(done, "custom fields "+strings.Join(applied, ", "))
+	}
+	if len(done) == 0 {
+		return handleError(err)
+	}
+	code, message := "ERROR", err.Error()
+	var clientErr *api.ClientError
+	if errors.As(err, &clientErr) {
+		code, message = clientErr.Code, clientErr.Message
+	}
+	output.PrintError(code, fmt.Sprintf("custom field %s: %s; already applied: %s", fieldID, message, strings.Join(done, " and ")))
+	if code == "UNAUTHORIZED" {
+		return &exitError{code: 2}
+	}
+	return &exitError{code: 1}
+}
+
 var taskDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a task",
@@ -295,8 +453,7 @@ var taskDeleteCmd = &cobra.Command{
 		if err := client.DeleteTask(ctx, id, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"message": "task deleted", "id": id})
-		return nil
+		return output.Print(map[string]string{"message": "task deleted", "id": id})
 	},
 }
 
@@ -318,14 +475,18 @@ var taskSearchCmd = &cobra.Command{
 		opts.Subtasks, _ = cmd.Flags().GetBool("subtasks")
 		opts.IncludeClosed, _ = cmd.Flags().GetBool("include-closed")
 		opts.IncludeMarkdown, _ = cmd.Flags().GetBool("include-markdown")
-		opts.DueDateGt, _ = cmd.Flags().GetInt64("due-date-gt")
-		opts.DueDateLt, _ = cmd.Flags().GetInt64("due-date-lt")
-		opts.DateCreatedGt, _ = cmd.Flags().GetInt64("date-created-gt")
-		opts.DateCreatedLt, _ = cmd.Flags().GetInt64("date-created-lt")
-		opts.DateUpdatedGt, _ = cmd.Flags().GetInt64("date-updated-gt")
-		opts.DateUpdatedLt, _ = cmd.Flags().GetInt64("date-updated-lt")
-		opts.DateDoneGt, _ = cmd.Flags().GetInt64("date-done-gt")
-		opts.DateDoneLt, _ = cmd.Flags().GetInt64("date-done-lt")
+		if err := getDates(cmd, map[string]*int64{
+			"due-date-gt":     &opts.DueDateGt,
+			"due-date-lt":     &opts.DueDateLt,
+			"date-created-gt": &opts.DateCreatedGt,
+			"date-created-lt": &opts.DateCreatedLt,
+			"date-updated-gt": &opts.DateUpdatedGt,
+			"date-updated-lt": &opts.DateUpdatedLt,
+			"date-done-gt":    &opts.DateDoneGt,
+			"date-done-lt":    &opts.DateDoneLt,
+		}); err != nil {
+			return err
+		}
 		opts.CustomFields, _ = cmd.Flags().GetString("custom-fields")
 		opts.CustomItems, _ = cmd.Flags().GetIntSlice("custom-items")
 		opts.ListIDs, _ = cmd.Flags().GetStringSlice("list-ids")
@@ -333,12 +494,21 @@ var taskSearchCmd = &cobra.Command{
 		opts.SpaceIDs, _ = cmd.Flags().GetStringSlice("space-ids")
 		opts.FolderIDs, _ = cmd.Flags().GetStringSlice("folder-ids")
 
+		popts, err := getPaginateOpts(cmd)
+		if err != nil {
+			return err
+		}
+		if popts != nil {
+			return printPages(ctx, api.SearchTasksPages(client, teamID, opts), *popts, func(tasks []api.Task, complete bool) interface{} {
+				return &api.TasksResponse{Tasks: tasks, LastPage: complete}
+			})
+		}
+
 		resp, err := client.SearchTasks(ctx, teamID, opts)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -360,8 +530,7 @@ var taskMergeCmd = &cobra.Command{
 		if err := client.MergeTasks(ctx, taskID, req, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -384,16 +553,14 @@ var taskTimeInStatusCmd = &cobra.Command{
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 
 		resp, err := client.GetTimeInStatus(ctx, taskID, getTaskScopedOpts(cmd))
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -414,8 +581,7 @@ var taskAddToListCmd = &cobra.Command{
 		if err := client.AddTaskToList(ctx, listID, taskID, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -436,16 +602,15 @@ var taskRemoveFromListCmd = &cobra.Command{
 		if err := client.RemoveTaskFromList(ctx, listID, taskID, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
 func init() {
 	// task list
-	taskListCmd.Flags().String("list", "", "List ID")
-	taskListCmd.Flags().StringSlice("status", nil, "Filter by status")
-	taskListCmd.Flags().StringSlice("assignee", nil, "Filter by assignee")
+	taskListCmd.Flags().String("list", "", "List ID or name (defaults to the default_list setting)")
+	taskListCmd.Flags().StringSlice("status", nil, "Filter by status (any case)")
+	taskListCmd.Flags().StringSlice("assignee", nil, "Filter by assignee (ID, email, username or me)")
 	taskListCmd.Flags().StringSlice("tag", nil, "Filter by tag")
 	taskListCmd.Flags().StringSlice("watchers", nil, "Filter by watchers")
 	taskListCmd.Flags().Int("page", 0, "Page number")
@@ -456,16 +621,17 @@ func init() {
 	taskListCmd.Flags().Bool("archived", false, "Filter archived tasks")
 	taskListCmd.Flags().Bool("include-markdown", false, "Include markdown description")
 	taskListCmd.Flags().Bool("include-timl", false, "Include tasks in multiple lists")
-	taskListCmd.Flags().Int64("due-date-gt", 0, "Due date greater than (Unix ms)")
-	taskListCmd.Flags().Int64("due-date-lt", 0, "Due date less than (Unix ms)")
-	taskListCmd.Flags().Int64("date-created-gt", 0, "Date created greater than (Unix ms)")
-	taskListCmd.Flags().Int64("date-created-lt", 0, "Date created less than (Unix ms)")
-	taskListCmd.Flags().Int64("date-updated-gt", 0, "Date updated greater than (Unix ms)")
-	taskListCmd.Flags().Int64("date-updated-lt", 0, "Date updated less than (Unix ms)")
-	taskListCmd.Flags().Int64("date-done-gt", 0, "Date done greater than (Unix ms)")
-	taskListCmd.Flags().Int64("date-done-lt", 0, "Date done less than (Unix ms)")
+	taskListCmd.Flags().String("due-date-gt", "", "Due date greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("due-date-lt", "", "Due date less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("date-created-gt", "", "Date created greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("date-created-lt", "", "Date created less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("date-updated-gt", "", "Date updated greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("date-updated-lt", "", "Date updated less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("date-done-gt", "", "Date done greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskListCmd.Flags().String("date-done-lt", "", "Date done less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
 	taskListCmd.Flags().String("custom-fields", "", "Custom fields filter (JSON array)")
 	taskListCmd.Flags().IntSlice("custom-items", nil, "Filter by task type (custom item IDs)")
+	addPaginationFlags(taskListCmd)
 
 	// task get
 	taskGetCmd.Flags().String("id", "", "Task ID")
@@ -475,17 +641,17 @@ func init() {
 	taskGetCmd.Flags().Bool("include-markdown", false, "Include markdown description")
 
 	// task create
-	taskCreateCmd.Flags().String("list", "", "List ID")
+	taskCreateCmd.Flags().String("list", "", "List ID or name (defaults to the default_list setting)")
 	taskCreateCmd.Flags().String("name", "", "Task name")
 	taskCreateCmd.Flags().String("description", "", "Task description")
 	taskCreateCmd.Flags().String("markdown-description", "", "Task description in markdown")
-	taskCreateCmd.Flags().IntSlice("assignee", nil, "Assignee user IDs")
-	taskCreateCmd.Flags().String("status", "", "Task status")
+	taskCreateCmd.Flags().StringSlice("assignee", nil, "Assignees (ID, email, username or me)")
+	taskCreateCmd.Flags().String("status", "", "Task status (any case)")
 	taskCreateCmd.Flags().Int("priority", 0, "Priority (1=urgent, 2=high, 3=normal, 4=low)")
 	taskCreateCmd.Flags().StringSlice("tag", nil, "Task tags")
-	taskCreateCmd.Flags().Int64("due-date", 0, "Due date (Unix ms)")
+	taskCreateCmd.Flags().String("due-date", "", "Due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	taskCreateCmd.Flags().Bool("due-date-time", false, "Due date includes time")
-	taskCreateCmd.Flags().Int64("start-date", 0, "Start date (Unix ms)")
+	taskCreateCmd.Flags().String("start-date", "", "Start date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	taskCreateCmd.Flags().Bool("start-date-time", false, "Start date includes time")
 	taskCreateCmd.Flags().Int64("time-estimate", 0, "Time estimate (ms)")
 	taskCreateCmd.Flags().Bool("notify-all", false, "Notify all assignees")
@@ -497,18 +663,19 @@ func init() {
 	taskCreateCmd.Flags().Int("custom-item-id", 0, "Custom task type ID")
 	taskCreateCmd.Flags().Bool("check-required-custom-fields", false, "Validate required custom fields")
 	taskCreateCmd.Flags().String("markdown-content", "", "Alias for --markdown-description")
+	taskCreateCmd.Flags().StringArray("field", nil, `Custom field value as "Name=Value", by field name or ID (repeatable)`)
 
 	// task update
 	taskUpdateCmd.Flags().String("id", "", "Task ID")
 	taskUpdateCmd.Flags().String("name", "", "Task name")
 	taskUpdateCmd.Flags().String("description", "", "Task description")
-	taskUpdateCmd.Flags().String("status", "", "Task status")
+	taskUpdateCmd.Flags().String("status", "", "Task status (any case)")
 	taskUpdateCmd.Flags().Int("priority", 0, "Priority (1=urgent, 2=high, 3=normal, 4=low)")
-	taskUpdateCmd.Flags().IntSlice("assignees-add", nil, "Assignee IDs to add")
-	taskUpdateCmd.Flags().IntSlice("assignees-rem", nil, "Assignee IDs to remove")
-	taskUpdateCmd.Flags().Int64("due-date", 0, "Due date (Unix ms)")
+	taskUpdateCmd.Flags().StringSlice("assignees-add", nil, "Assignees to add (ID, email, username or me)")
+	taskUpdateCmd.Flags().StringSlice("assignees-rem", nil, "Assignees to remove (ID, email, username or me)")
+	taskUpdateCmd.Flags().String("due-date", "", "Due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	taskUpdateCmd.Flags().Bool("due-date-time", false, "Due date includes time")
-	taskUpdateCmd.Flags().Int64("start-date", 0, "Start date (Unix ms)")
+	taskUpdateCmd.Flags().String("start-date", "", "Start date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	taskUpdateCmd.Flags().Bool("start-date-time", false, "Start date includes time")
 	taskUpdateCmd.Flags().Int64("time-estimate", 0, "Time estimate (ms)")
 	taskUpdateCmd.Flags().Bool("archived", false, "Archive task")
@@ -520,6 +687,7 @@ func init() {
 	taskUpdateCmd.Flags().StringSlice("group-assignees-rem", nil, "Group assignee IDs to remove")
 	taskUpdateCmd.Flags().Bool("custom-task-ids", false, "Use custom task IDs")
 	taskUpdateCmd.Flags().String("team-id", "", "Team ID (required when custom-task-ids=true)")
+	taskUpdateCmd.Flags().StringArray("field", nil, `Custom field value as "Name=Value", by field name or ID (repeatable)`)
 
 	// task delete
 	taskDeleteCmd.Flags().String("id", "", "Task ID")
@@ -528,7 +696,7 @@ func init() {
 	// task search
 	taskSearchCmd.Flags().String("workspace", "", "Workspace/Team ID")
 	taskSearchCmd.Flags().StringSlice("status", nil, "Filter by status")
-	taskSearchCmd.Flags().StringSlice("assignee", nil, "Filter by assignee")
+	taskSearchCmd.Flags().StringSlice("assignee", nil, "Filter by assignee (ID, email, username or me)")
 	taskSearchCmd.Flags().StringSlice("tag", nil, "Filter by tag")
 	taskSearchCmd.Flags().Int("page", 0, "Page number")
 	taskSearchCmd.Flags().String("order-by", "", "Order by field")
@@ -536,20 +704,21 @@ func init() {
 	taskSearchCmd.Flags().Bool("subtasks", false, "Include subtasks")
 	taskSearchCmd.Flags().Bool("include-closed", false, "Include closed tasks")
 	taskSearchCmd.Flags().Bool("include-markdown", false, "Include markdown description")
-	taskSearchCmd.Flags().Int64("due-date-gt", 0, "Due date greater than (Unix ms)")
-	taskSearchCmd.Flags().Int64("due-date-lt", 0, "Due date less than (Unix ms)")
-	taskSearchCmd.Flags().Int64("date-created-gt", 0, "Date created greater than (Unix ms)")
-	taskSearchCmd.Flags().Int64("date-created-lt", 0, "Date created less than (Unix ms)")
-	taskSearchCmd.Flags().Int64("date-updated-gt", 0, "Date updated greater than (Unix ms)")
-	taskSearchCmd.Flags().Int64("date-updated-lt", 0, "Date updated less than (Unix ms)")
-	taskSearchCmd.Flags().Int64("date-done-gt", 0, "Date done greater than (Unix ms)")
-	taskSearchCmd.Flags().Int64("date-done-lt", 0, "Date done less than (Unix ms)")
+	taskSearchCmd.Flags().String("due-date-gt", "", "Due date greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("due-date-lt", "", "Due date less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("date-created-gt", "", "Date created greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("date-created-lt", "", "Date created less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("date-updated-gt", "", "Date updated greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("date-updated-lt", "", "Date updated less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("date-done-gt", "", "Date done greater than (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	taskSearchCmd.Flags().String("date-done-lt", "", "Date done less than (date: 2026-03-01, -7d, yesterday, Unix ms)")
 	taskSearchCmd.Flags().String("custom-fields", "", "Custom fields filter (JSON array)")
 	taskSearchCmd.Flags().IntSlice("custom-items", nil, "Filter by task type")
 	taskSearchCmd.Flags().StringSlice("list-ids", nil, "Filter by list IDs")
 	taskSearchCmd.Flags().StringSlice("project-ids", nil, "Filter by project/folder IDs")
 	taskSearchCmd.Flags().StringSlice("space-ids", nil, "Filter by space IDs")
 	taskSearchCmd.Flags().StringSlice("folder-ids", nil, "Filter by folder IDs")
+	addPaginationFlags(taskSearchCmd)
 
 	// task merge
 	taskMergeCmd.Flags().String("id", "", "Task ID (required)")
@@ -562,12 +731,12 @@ func init() {
 	addTaskScopedFlags(taskTimeInStatusCmd)
 
 	// task add-to-list
-	taskAddToListCmd.Flags().String("list", "", "List ID (required)")
+	taskAddToListCmd.Flags().String("list", "", "List ID or name (required)")
 	taskAddToListCmd.Flags().String("id", "", "Task ID (required)")
 	addTaskScopedFlags(taskAddToListCmd)
 
 	// task remove-from-list
-	taskRemoveFromListCmd.Flags().String("list", "", "List ID (required)")
+	taskRemoveFromListCmd.Flags().String("list", "", "List ID or name (required)")
 	taskRemoveFromListCmd.Flags().String("id", "", "Task ID (required)")
 	addTaskScopedFlags(taskRemoveFromListCmd)
 
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/bulk"
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/blockful/clickup-cli/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+var taskBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update",
+	Short: "Update many tasks, read from --ids or --ids-from",
+	Long: `Apply the same changes to many tasks, several at a time. Task IDs come
+from --ids and from --ids-from, one per line ("-" reads stdin; blank lines
+and lines starting with # are skipped).
+
+Prints a summary with one result per task and exits 1 if any task failed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := getClient()
+		ctx := context.Background()
+		ids, _ := cmd.Flags().GetStringSlice("ids")
+		if from, _ := cmd.Flags().GetString("ids-from"); from != "" {
+			lines, err := readLines(from)
+			if err != nil {
+				output.PrintError("VALIDATION_ERROR", fmt.Sprintf("--ids-from: %v", err))
+				return &exitError{code: 1}
+			}
+			n := len(ids)
+			for _, line := range lines {
+				if !strings.HasPrefix(line, "#") {
+					ids = append(ids, line)
+				}
+			}
+			if len(ids) == n {
+				output.PrintError("VALIDATION_ERROR", "--ids-from: no task IDs in "+from)
+				return &exitError{code: 1}
+			}
+		}
+		if len(ids) == 0 {
+			output.PrintError("VALIDATION_ERROR", "no task IDs: use --ids or --ids-from")
+			return &exitError{code: 1}
+		}
+		concurrency, err := getConcurrency(cmd)
+		if err != nil {
+			return err
+		}
+
+		req := &api.UpdateTaskRequest{}
+		changed := false
+		if cmd.Flags().Changed("priority") {
+			v, _ := cmd.Flags().GetInt("priority")
+			req.Priority = api.IntPtr(v)
+			changed = true
+		}
+		if cmd.Flags().Changed("due-date") {
+			v, err := getDate(cmd, "due-date")
+			if err != nil {
+				return err
+			}
+			req.DueDate = api.Int64Ptr(v)
+			changed = true
+		}
+		if cmd.Flags().Changed("archived") {
+			v, _ := cmd.Flags().GetBool("archived")
+			req.Archived = api.BoolPtr(v)
+			changed = true
+		}
+		addAssignees := getUserIDs(cmd, "assignees-add")
+		remAssignees := getUserIDs(cmd, "assignees-rem")
+		if len(addAssignees) > 0 || len(remAssignees) > 0 {
+			req.Assignees = &api.UpdateTaskAssignees{Add: addAssignees, Rem: remAssignees}
+			changed = true
+		}
+		status, _ := cmd.Flags().GetString("status")
+		addTags, _ := cmd.Flags().GetStringSlice("add-tag")
+		remTags, _ := cmd.Flags().GetStringSlice("remove-tag")
+		if !changed && status == "" && len(addTags) == 0 && len(remTags) == 0 {
+			output.PrintError("VALIDATION_ERROR", "nothing to update: use --status, --priority, --due-date, --archived, --assignees-add/rem, --add-tag or --remove-tag")
+			return &exitError{code: 1}
+		}
+
+		opts := api.UpdateTaskOptions{}
+		opts.CustomTaskIDs, _ = cmd.Flags().GetBool("custom-task-ids")
+		opts.TeamID, _ = cmd.Flags().GetString("team-id")
+		scoped := getTaskScopedOpts(cmd)
+		r := getResolver(cmd)
+
+		summary := bulk.Run(ctx, len(ids), concurrency, func(ctx context.Context, i int) (string, interface{}, error) {
+			id := ids[i]
+			taskReq := *req
+			if status != "" {
+				// Statuses belong to the task's list, so each task's list is looked up.
+				task, err := client.GetTask(ctx, id, api.GetTaskOptions{CustomTaskIDs: opts.CustomTaskIDs, TeamID: opts.TeamID})
+				if err != nil {
+					return id, nil, err
+				}
+				s, err := r.Status(ctx, status, task.List.ID)
+				if err != nil {
+					return id, nil, err
+				}
+				taskReq.Status = api.StringPtr(s)
+			}
+			if changed || status != "" {
+				if _, err := client.UpdateTask(ctx, id, &taskReq, opts); err != nil {
+					return id, nil, err
+				}
+			}
+			for _, tag := range addTags {
+				if err := client.AddTagToTask(ctx, id, tag, scoped); err != nil {
+					return id, nil, err
+				}
+			}
+			for _, tag := range remTags {
+				if err := client.RemoveTagFromTask(ctx, id, tag, scoped); err != nil {
+					return id, nil, err
+				}
+			}
+			return id, nil, nil
+		})
+		return printSummary(summary)
+	},
+}
+
+// bulkTask is a line of a bulk-create file: the task create request body,
+// plus the list to create it in.
+type bulkTask struct {
+	List string `json:"list,omitempty"`
+	api.CreateTaskRequest
+}
+
+var taskBulkCreateCmd = &cobra.Command{
+	Use:   "bulk-create",
+	Short: "Create many tasks from a JSON Lines file",
+	Long: `Create one task per line of --from ("-" reads stdin). Each line is a JSON
+object with the fields of the task create request body ("name", "status",
+"assignees", "tags", "due_date", ...) and an optional "list" (ID or name)
+that overrides --list. Blank lines are skipped.
+
+Every line is checked before any task is created. Prints a summary with one
+result per line and exits 1 if any task failed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := getClient()
+		ctx := context.Background()
+		from, _ := cmd.Flags().GetString("from")
+		if from == "" {
+			output.PrintError("VALIDATION_ERROR", "--from is required")
+			return &exitError{code: 1}
+		}
+		lines, err := readLines(from)
+		if err != nil {
+			output.PrintError("VALIDATION_ERROR", fmt.Sprintf("--from: %v", err))
+			return &exitError{code: 1}
+		}
+		if len(lines) == 0 {
+			output.PrintError("VALIDATION_ERROR", "--from: no tasks in "+from)
+			return &exitError{code: 1}
+		}
+		concurrency, err := getConcurrency(cmd)
+		if err != nil {
+			return err
+		}
+
+		defaultList := getListID(cmd)
+		r := getResolver(cmd)
+		tasks := make([]bulkTask, len(lines))
+		for i, line := range lines {
+			t := &tasks[i]
+			where := fmt.Sprintf("line %d", i+1)
+			if err := json.Unmarshal([]byte(line), t); err != nil {
+				output.PrintError("VALIDATION_ERROR", fmt.Sprintf("%s: invalid JSON: %v", where, err))
+				return &exitError{code: 1}
+			}
+			if t.Name == "" {
+				output.PrintError("VALIDATION_ERROR", where+": name is required")
+				return &exitError{code: 1}
+			}
+			if t.List == "" {
+				t.List = defaultList
+			}
+			if t.List == "" {
+				output.PrintError("VALIDATION_ERROR", where+": list is required (or use --list, or set default_list)")
+				return &exitError{code: 1}
+			}
+			if !resolve.IsID(t.List) {
+				if t.List, err = r.List(ctx, t.List, ""); err != nil {
+					return resolveError(where+": list", err)
+				}
+			}
+			if t.Status != "" {
+				if t.Status, err = r.Status(ctx, t.Status, t.List); err != nil {
+					return resolveError(where+": status", err)
+				}
+			}
+		}
+
+		summary := bulk.Run(ctx, len(tasks), concurrency, func(ctx context.Context, i int) (string, interface{}, error) {
+			task, err := client.CreateTask(ctx, tasks[i].List, &tasks[i].CreateTaskRequest)
+			if err != nil {
+				return "", nil, err
+			}
+			return task.ID, map[string]string{"name": task.Name, "url": task.URL}, nil
+		})
+		return printSummary(summary)
+	},
+}
+
+// readLines returns the non-blank lines of path, or of stdin for "-", with
+// surrounding space trimmed.
+func readLines(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var lines []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, sc.Err()
+}
+
+// getConcurrency reads --concurrency, which must be at least 1.
+func getConcurrency(cmd *cobra.Command) (int, error) {
+	n, _ := cmd.Flags().GetInt("concurrency")
+	if n < 1 {
+		output.PrintError("VALIDATION_ERROR", "--concurrency must be at least 1")
+		return 0, &exitError{code: 1}
+	}
+	return n, nil
+}
+
+// printSummary prints a bulk summary and fails the command if any item
+// failed, so scripts can tell a partial failure from success.
+func printSummary(s *bulk.Summary) error {
+	if err := output.Print(s); err != nil {
+		return err
+	}
+	if s.Failed > 0 {
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+func init() {
+	taskBulkUpdateCmd.Flags().StringSlice("ids", nil, "Task IDs")
+	taskBulkUpdateCmd.Flags().String("ids-from", "", "File with one task ID per line (- for stdin)")
+	taskBulkUpdateCmd.Flags().String("status", "", "New status (any case)")
+	taskBulkUpdateCmd.Flags().Int("priority", 0, "Priority (1=urgent, 2=high, 3=normal, 4=low)")
+	taskBulkUpdateCmd.Flags().String("due-date", "", "Due date (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
+	taskBulkUpdateCmd.Flags().Bool("archived", false, "Archive tasks")
+	taskBulkUpdateCmd.Flags().StringSlice("assignees-add", nil, "Assignees to add (ID, email, username or me)")
+	taskBulkUpdateCmd.Flags().StringSlice("assignees-rem", nil, "Assignees to remove (ID, email, username or me)")
+	taskBulkUpdateCmd.Flags().StringSlice("add-tag", nil, "Tags to add")
+	taskBulkUpdateCmd.Flags().StringSlice("remove-tag", nil, "Tags to remove")
+	taskBulkUpdateCmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "Tasks to update at once")
+	addTaskScopedFlags(taskBulkUpdateCmd)
+
+	taskBulkCreateCmd.Flags().String("from", "", "JSON Lines file, one task per line (- for stdin)")
+	taskBulkCreateCmd.Flags().String("list", "", "List ID or name for lines without one (defaults to the default_list setting)")
+	taskBulkCreateCmd.Flags().Int("concurrency", bulk.DefaultConcurrency, "Tasks to create at once")
+
+	taskCmd.AddCommand(taskBulkUpdateCmd, taskBulkCreateCmd)
+}
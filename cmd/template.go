@@ -22,12 +22,20 @@ var templateListCmd = &cobra.Command{
 		ctx := context.Background()
 		wid := getWorkspaceID(cmd)
 		page, _ := cmd.Flags().GetInt("page")
+		popts, err := getPaginateOpts(cmd)
+		if err != nil {
+			return err
+		}
+		if popts != nil {
+			return printPages(ctx, api.TaskTemplatesPages(client, wid), *popts, func(templates []api.TaskTemplate, complete bool) interface{} {
+				return &api.TaskTemplatesResponse{Templates: templates, LastPage: api.BoolPtr(complete)}
+			})
+		}
 		resp, err := client.GetTaskTemplates(ctx, wid, page)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -56,8 +64,7 @@ var templateCreateTaskCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -93,8 +100,7 @@ var templateCreateFolderCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -132,32 +138,31 @@ var templateCreateListCmd = &cobra.Command{
 			if err != nil {
 				return handleError(err)
 			}
-			output.JSON(resp)
-			return nil
+			return output.Print(resp)
 		}
 		resp, err := client.CreateListFromSpaceTemplate(ctx, spaceID, templateID, req)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
 func init() {
 	templateListCmd.Flags().Int("page", 0, "Page number")
+	addPaginationFlags(templateListCmd)
 
-	templateCreateTaskCmd.Flags().String("list", "", "List ID (required)")
+	templateCreateTaskCmd.Flags().String("list", "", "List ID or name (required)")
 	templateCreateTaskCmd.Flags().String("template-id", "", "Template ID (required)")
 	templateCreateTaskCmd.Flags().String("name", "", "Task name (required)")
 
-	templateCreateFolderCmd.Flags().String("space", "", "Space ID (required)")
+	templateCreateFolderCmd.Flags().String("space", "", "Space ID or name (required)")
 	templateCreateFolderCmd.Flags().String("template-id", "", "Template ID (required)")
 	templateCreateFolderCmd.Flags().String("name", "", "Folder name (required)")
 	templateCreateFolderCmd.Flags().String("options", "", "Template options (JSON)")
 
-	templateCreateListCmd.Flags().String("folder", "", "Folder ID")
-	templateCreateListCmd.Flags().String("space", "", "Space ID")
+	templateCreateListCmd.Flags().String("folder", "", "Folder ID or name")
+	templateCreateListCmd.Flags().String("space", "", "Space ID or name")
 	templateCreateListCmd.Flags().String("template-id", "", "Template ID (required)")
 	templateCreateListCmd.Flags().String("name", "", "List name (required)")
 	templateCreateListCmd.Flags().String("options", "", "Template options (JSON)")
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/blockful/clickup-cli/internal/api"
 	"github.com/blockful/clickup-cli/internal/output"
@@ -22,8 +23,16 @@ var timeEntryListCmd = &cobra.Command{
 		wid := getWorkspaceID(cmd)
 
 		opts := &api.ListTimeEntriesOptions{}
-		opts.StartDate, _ = cmd.Flags().GetString("start-date")
-		opts.EndDate, _ = cmd.Flags().GetString("end-date")
+		var start, end int64
+		if err := getDates(cmd, map[string]*int64{"start-date": &start, "end-date": &end}); err != nil {
+			return err
+		}
+		if start != 0 {
+			opts.StartDate = strconv.FormatInt(start, 10)
+		}
+		if end != 0 {
+			opts.EndDate = strconv.FormatInt(end, 10)
+		}
 		opts.Assignee, _ = cmd.Flags().GetString("assignee")
 		opts.SpaceID, _ = cmd.Flags().GetString("space")
 		opts.FolderID, _ = cmd.Flags().GetString("folder")
@@ -42,8 +51,7 @@ var timeEntryListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -66,8 +74,7 @@ var timeEntryGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -79,7 +86,10 @@ var timeEntryCreateCmd = &cobra.Command{
 		ctx := context.Background()
 		wid := getWorkspaceID(cmd)
 
-		start, _ := cmd.Flags().GetInt64("start")
+		start, err := getDate(cmd, "start")
+		if err != nil {
+			return err
+		}
 		duration, _ := cmd.Flags().GetInt64("duration")
 		description, _ := cmd.Flags().GetString("description")
 		tid, _ := cmd.Flags().GetString("task")
@@ -87,7 +97,10 @@ var timeEntryCreateCmd = &cobra.Command{
 			tid = t
 		}
 		billable, _ := cmd.Flags().GetBool("billable")
-		stop, _ := cmd.Flags().GetInt64("stop")
+		stop, err := getDate(cmd, "stop")
+		if err != nil {
+			return err
+		}
 
 		if start == 0 || duration == 0 {
 			output.PrintError("VALIDATION_ERROR", "--start and --duration are required")
@@ -109,8 +122,7 @@ var timeEntryCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -144,8 +156,7 @@ var timeEntryUpdateCmd = &cobra.Command{
 		if err := client.UpdateTimeEntry(ctx, wid, id, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -164,8 +175,7 @@ var timeEntryDeleteCmd = &cobra.Command{
 		if err := client.DeleteTimeEntry(ctx, wid, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -193,8 +203,7 @@ var timeEntryStartCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -209,8 +218,7 @@ var timeEntryStopCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -226,8 +234,7 @@ var timeEntryCurrentCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -235,12 +242,12 @@ func init() {
 	rootCmd.AddCommand(timeEntryCmd)
 	timeEntryCmd.AddCommand(timeEntryListCmd, timeEntryGetCmd, timeEntryCreateCmd, timeEntryUpdateCmd, timeEntryDeleteCmd, timeEntryStartCmd, timeEntryStopCmd, timeEntryCurrentCmd)
 
-	timeEntryListCmd.Flags().String("start-date", "", "Start date (unix ms)")
-	timeEntryListCmd.Flags().String("end-date", "", "End date (unix ms)")
-	timeEntryListCmd.Flags().String("assignee", "", "Assignee user ID")
-	timeEntryListCmd.Flags().String("space", "", "Space ID filter")
-	timeEntryListCmd.Flags().String("folder", "", "Folder ID filter")
-	timeEntryListCmd.Flags().String("list", "", "List ID filter")
+	timeEntryListCmd.Flags().String("start-date", "", "Start of the range (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	timeEntryListCmd.Flags().String("end-date", "", "End of the range (date: 2026-03-01, -7d, yesterday, Unix ms)")
+	timeEntryListCmd.Flags().String("assignee", "", "Assignee user ID, email, username or me (comma-separated)")
+	timeEntryListCmd.Flags().String("space", "", "Filter by space ID or name")
+	timeEntryListCmd.Flags().String("folder", "", "Filter by folder ID or name")
+	timeEntryListCmd.Flags().String("list", "", "Filter by list ID or name")
 	timeEntryListCmd.Flags().String("task", "", "Task ID filter")
 	timeEntryListCmd.Flags().Bool("include-task-tags", false, "Include task tags")
 	timeEntryListCmd.Flags().Bool("include-location-names", false, "Include location names")
@@ -252,12 +259,12 @@ func init() {
 	timeEntryGetCmd.Flags().Bool("include-approval-history", false, "Include approval history")
 	timeEntryGetCmd.Flags().Bool("include-approval-details", false, "Include approval details")
 
-	timeEntryCreateCmd.Flags().Int64("start", 0, "Start time (unix ms, required)")
+	timeEntryCreateCmd.Flags().String("start", "", "Start time, required (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	timeEntryCreateCmd.Flags().Int64("duration", 0, "Duration (ms, required)")
 	timeEntryCreateCmd.Flags().String("description", "", "Description")
 	timeEntryCreateCmd.Flags().String("task", "", "Task ID")
 	timeEntryCreateCmd.Flags().String("tid", "", "Task ID (alias for --task)")
-	timeEntryCreateCmd.Flags().Int64("stop", 0, "Stop time (unix ms)")
+	timeEntryCreateCmd.Flags().String("stop", "", "Stop time (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	timeEntryCreateCmd.Flags().Bool("billable", false, "Billable")
 
 	timeEntryUpdateCmd.Flags().String("id", "", "Time entry ID (required)")
@@ -273,5 +280,5 @@ func init() {
 	timeEntryStartCmd.Flags().String("description", "", "Description")
 	timeEntryStartCmd.Flags().Bool("billable", false, "Billable")
 
-	timeEntryCurrentCmd.Flags().String("assignee", "", "Assignee user ID")
+	timeEntryCurrentCmd.Flags().String("assignee", "", "Assignee user ID, email, username or me")
 }
@@ -30,8 +30,7 @@ var legacyListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -51,8 +50,10 @@ var legacyCreateCmd = &cobra.Command{
 			output.PrintError("VALIDATION_ERROR", "--time is required")
 			return &exitError{code: 1}
 		}
-		start, _ := cmd.Flags().GetInt64("start")
-		end, _ := cmd.Flags().GetInt64("end")
+		var start, end int64
+		if err := getDates(cmd, map[string]*int64{"start": &start, "end": &end}); err != nil {
+			return err
+		}
 		tagsStr, _ := cmd.Flags().GetString("tags")
 
 		req := &api.LegacyTrackTimeRequest{
@@ -70,8 +71,7 @@ var legacyCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -93,10 +93,18 @@ var legacyUpdateCmd = &cobra.Command{
 			req.Time, _ = cmd.Flags().GetInt64("time")
 		}
 		if cmd.Flags().Changed("start") {
-			req.Start, _ = cmd.Flags().GetInt64("start")
+			v, err := getDate(cmd, "start")
+			if err != nil {
+				return err
+			}
+			req.Start = v
 		}
 		if cmd.Flags().Changed("end") {
-			req.End, _ = cmd.Flags().GetInt64("end")
+			v, err := getDate(cmd, "end")
+			if err != nil {
+				return err
+			}
+			req.End = v
 		}
 		if cmd.Flags().Changed("tag-action") {
 			req.TagAction, _ = cmd.Flags().GetString("tag-action")
@@ -111,8 +119,7 @@ var legacyUpdateCmd = &cobra.Command{
 		if err := client.EditLegacyTime(ctx, taskID, intervalID, req, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -131,8 +138,7 @@ var legacyDeleteCmd = &cobra.Command{
 		if err := client.DeleteLegacyTime(ctx, taskID, intervalID, getTaskScopedOpts(cmd)); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -146,16 +152,16 @@ func init() {
 
 	legacyCreateCmd.Flags().String("task-id", "", "Task ID (required)")
 	legacyCreateCmd.Flags().Int64("time", 0, "Time in milliseconds (required)")
-	legacyCreateCmd.Flags().Int64("start", 0, "Start date (unix ms)")
-	legacyCreateCmd.Flags().Int64("end", 0, "End date (unix ms)")
+	legacyCreateCmd.Flags().String("start", "", "Start time (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
+	legacyCreateCmd.Flags().String("end", "", "End time (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	legacyCreateCmd.Flags().String("tags", "", "Comma-separated tag names")
 	addTaskScopedFlags(legacyCreateCmd)
 
 	legacyUpdateCmd.Flags().String("task-id", "", "Task ID (required)")
 	legacyUpdateCmd.Flags().String("interval-id", "", "Interval ID (required)")
 	legacyUpdateCmd.Flags().Int64("time", 0, "Time in milliseconds")
-	legacyUpdateCmd.Flags().Int64("start", 0, "Start date (unix ms)")
-	legacyUpdateCmd.Flags().Int64("end", 0, "End date (unix ms)")
+	legacyUpdateCmd.Flags().String("start", "", "Start time (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
+	legacyUpdateCmd.Flags().String("end", "", "End time (date: 2026-03-01T17:00, tomorrow, next friday, eow, Unix ms)")
 	legacyUpdateCmd.Flags().String("tag-action", "", "Tag action (add/replace)")
 	legacyUpdateCmd.Flags().String("tags", "", "Comma-separated tag names")
 	addTaskScopedFlags(legacyUpdateCmd)
@@ -30,8 +30,7 @@ var timeEntryHistoryCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -59,8 +58,7 @@ var timeEntryTagAddCmd = &cobra.Command{
 		if err := client.AddTagsToTimeEntries(ctx, wid, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -88,8 +86,7 @@ var timeEntryTagRemoveCmd = &cobra.Command{
 		if err := client.RemoveTagsFromTimeEntries(ctx, wid, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -117,8 +114,7 @@ var timeEntryTagUpdateCmd = &cobra.Command{
 		if err := client.ChangeTagNames(ctx, wid, req); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
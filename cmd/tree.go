@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/blockful/clickup-cli/internal/tree"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the spaces, folders and lists of a workspace as a tree",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := getClient()
+		ctx := context.Background()
+		wsID := getWorkspaceID(cmd)
+		opts := tree.Options{}
+		opts.SpaceID, _ = cmd.Flags().GetString("space")
+		opts.Depth, _ = cmd.Flags().GetInt("depth")
+		opts.TaskCounts, _ = cmd.Flags().GetBool("tasks")
+		if opts.Depth < 0 || opts.Depth > 3 {
+			output.PrintError("VALIDATION_ERROR", "--depth must be between 0 and 3")
+			return &exitError{code: 1}
+		}
+		root, err := tree.Walk(ctx, client, wsID, opts)
+		if err != nil {
+			return handleError(err)
+		}
+		return output.Print(root)
+	},
+}
+
+func init() {
+	treeCmd.Flags().String("space", "", "Only show this space (ID or name)")
+	treeCmd.Flags().Int("depth", 0, "Levels to show: 1 spaces, 2 folders and folderless lists, 3 lists in folders (0 = all)")
+	treeCmd.Flags().Bool("tasks", false, "Show task counts as reported by ClickUp, summed per folder and space")
+
+	rootCmd.AddCommand(treeCmd)
+}
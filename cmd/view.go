@@ -54,8 +54,7 @@ var viewListCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -74,8 +73,7 @@ var viewGetCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -125,8 +123,7 @@ var viewCreateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -160,8 +157,7 @@ var viewUpdateCmd = &cobra.Command{
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -179,8 +175,7 @@ var viewDeleteCmd = &cobra.Command{
 		if err := client.DeleteView(ctx, id); err != nil {
 			return handleError(err)
 		}
-		output.JSON(map[string]string{"status": "ok"})
-		return nil
+		return output.Print(map[string]string{"status": "ok"})
 	},
 }
 
@@ -196,12 +191,20 @@ var viewTasksCmd = &cobra.Command{
 			output.PrintError("VALIDATION_ERROR", "--id is required")
 			return &exitError{code: 1}
 		}
+		popts, err := getPaginateOpts(cmd)
+		if err != nil {
+			return err
+		}
+		if popts != nil {
+			return printPages(ctx, api.ViewTasksPages(client, id), *popts, func(tasks []interface{}, complete bool) interface{} {
+				return &api.ViewTasksResponse{Tasks: tasks, LastPage: complete}
+			})
+		}
 		resp, err := client.GetViewTasks(ctx, id, page)
 		if err != nil {
 			return handleError(err)
 		}
-		output.JSON(resp)
-		return nil
+		return output.Print(resp)
 	},
 }
 
@@ -209,17 +212,17 @@ func init() {
 	rootCmd.AddCommand(viewCmd)
 	viewCmd.AddCommand(viewListCmd, viewGetCmd, viewCreateCmd, viewUpdateCmd, viewDeleteCmd, viewTasksCmd)
 
-	viewListCmd.Flags().String("space", "", "Space ID")
-	viewListCmd.Flags().String("folder", "", "Folder ID")
-	viewListCmd.Flags().String("list", "", "List ID")
+	viewListCmd.Flags().String("space", "", "Space ID or name")
+	viewListCmd.Flags().String("folder", "", "Folder ID or name")
+	viewListCmd.Flags().String("list", "", "List ID or name")
 
 	viewGetCmd.Flags().String("id", "", "View ID (required)")
 
 	viewCreateCmd.Flags().String("name", "", "View name (required)")
 	viewCreateCmd.Flags().String("type", "", "View type: list, board, calendar, etc. (required)")
-	viewCreateCmd.Flags().String("space", "", "Space ID")
-	viewCreateCmd.Flags().String("folder", "", "Folder ID")
-	viewCreateCmd.Flags().String("list", "", "List ID")
+	viewCreateCmd.Flags().String("space", "", "Space ID or name")
+	viewCreateCmd.Flags().String("folder", "", "Folder ID or name")
+	viewCreateCmd.Flags().String("list", "", "List ID or name")
 	viewCreateCmd.Flags().String("filters", "", "Filters config (JSON)")
 	viewCreateCmd.Flags().String("sorting", "", "Sorting config (JSON)")
 	viewCreateCmd.Flags().String("grouping", "", "Grouping config (JSON)")
@@ -243,4 +246,5 @@ func init() {
 
 	viewTasksCmd.Flags().String("id", "", "View ID (required)")
 	viewTasksCmd.Flags().Int("page", 0, "Page number")
+	addPaginationFlags(viewTasksCmd)
 }
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/output"
+	"github.com/blockful/clickup-cli/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+var webhookListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Receive webhook deliveries and print or forward them",
+	Long: `Run an HTTP server that receives ClickUp webhook deliveries, checks the
+X-Signature header against the webhook's secret and prints each event as a
+line of JSON. With --exec, each event is instead piped to a shell command;
+a failing command answers 500 so that ClickUp retries the delivery.
+
+Point a webhook at the server with "clickup webhook create --endpoint",
+using a tunnel when the machine is not reachable from the internet.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetInt("port")
+		path, _ := cmd.Flags().GetString("path")
+		secret, _ := cmd.Flags().GetString("secret")
+		command, _ := cmd.Flags().GetString("exec")
+		if secret == "" {
+			secret = os.Getenv("CLICKUP_WEBHOOK_SECRET")
+		}
+		if secret == "" {
+			output.PrintError("VALIDATION_ERROR", "--secret or CLICKUP_WEBHOOK_SECRET is required")
+			return &exitError{code: 1}
+		}
+		if port < 0 || port > 65535 {
+			output.PrintError("VALIDATION_ERROR", fmt.Sprintf("invalid --port %d", port))
+			return &exitError{code: 1}
+		}
+
+		ln, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		if err != nil {
+			output.PrintError("LISTEN_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		fmt.Fprintf(os.Stderr, "Listening for webhook deliveries on http://%s%s\n", ln.Addr(), path)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := serveWebhooks(ctx, ln, path, secret, webhookEventHandler(os.Stdout, os.Stderr, command)); err != nil {
+			output.PrintError("LISTEN_ERROR", err.Error())
+			return &exitError{code: 1}
+		}
+		return nil
+	},
+}
+
+// serveWebhooks serves deliveries posted to path on ln until ctx is done,
+// then waits for the ones in flight.
+func serveWebhooks(ctx context.Context, ln net.Listener, path, secret string, fn webhook.HandlerFunc) error {
+	mux := http.NewServeMux()
+	mux.Handle(path, webhook.Handler(secret, fn))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Serve(ln) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// webhookEventHandler writes each event's typed payload (see webhook.Parse)
+// to stdout as a line of JSON, or, when command is set, runs it with the
+// payload on stdin and its output on stdout and stderr. Events are handled
+// one at a time so that lines and command output do not interleave.
+func webhookEventHandler(stdout, stderr io.Writer, command string) webhook.HandlerFunc {
+	var mu sync.Mutex
+	return func(ctx context.Context, e *webhook.Event) error {
+		p, err := webhook.Parse(e)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if command == "" {
+			_, err := fmt.Fprintf(stdout, "%s\n", data)
+			return err
+		}
+		c := shellCommand(ctx, command)
+		c.Stdin = bytes.NewReader(data)
+		c.Stdout = stdout
+		c.Stderr = stderr
+		c.Env = append(os.Environ(),
+			"CLICKUP_EVENT="+e.Event,
+			"CLICKUP_WEBHOOK_ID="+e.WebhookID,
+		)
+		if err := c.Run(); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", e.Event, err)
+			return fmt.Errorf("command failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// shellCommand runs command through the platform's shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+func init() {
+	webhookListenCmd.Flags().String("host", "127.0.0.1", "Address to listen on (0.0.0.0 for all interfaces)")
+	webhookListenCmd.Flags().Int("port", 8080, "Port to listen on")
+	webhookListenCmd.Flags().String("path", "/", "URL path that receives deliveries")
+	webhookListenCmd.Flags().String("secret", "", "Webhook secret that signs deliveries (default $CLICKUP_WEBHOOK_SECRET)")
+	webhookListenCmd.Flags().String("exec", "", "Shell command to run for each event, with the event JSON on stdin")
+	webhookCmd.AddCommand(webhookListenCmd)
+}
@@ -30,6 +30,20 @@ func (c *Client) CreateTaskAttachment(ctx context.Context, taskID, filePath stri
 	}
 	defer file.Close()
 
+	var o *TaskScopedOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	path := fmt.Sprintf("/v2/task/%s/attachment", taskID) + taskScopedQuery(o)
+	if dr := c.dryRun("POST", path, nil); dr != nil {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, &ClientError{Code: "FILE_ERROR", Message: fmt.Sprintf("failed to read file: %v", err)}
+		}
+		dr.Request.File = &DryRunFile{Field: "attachment", Name: filepath.Base(filePath), Size: info.Size()}
+		return nil, dr
+	}
+
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
 	part, err := writer.CreateFormFile("attachment", filepath.Base(filePath))
@@ -41,30 +55,17 @@ func (c *Client) CreateTaskAttachment(ctx context.Context, taskID, filePath stri
 	}
 	writer.Close()
 
-	var o *TaskScopedOptions
-	if len(opts) > 0 {
-		o = opts[0]
-	}
-	url := c.BaseURL + fmt.Sprintf("/v2/task/%s/attachment", taskID) + taskScopedQuery(o)
+	url := c.BaseURL + path
 	req, err := http.NewRequestWithContext(ctx, "POST", url, &buf)
 	if err != nil {
 		return nil, &ClientError{Code: "REQUEST_ERROR", Message: fmt.Sprintf("failed to create request: %v", err)}
 	}
-	req.Header.Set("Authorization", c.Token)
+	c.setAuth(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		if ctx.Err() != nil {
-			return nil, &ClientError{Code: "CANCELLED", Message: "request cancelled"}
-		}
-		return nil, &ClientError{Code: "NETWORK_ERROR", Message: fmt.Sprintf("request failed: %v", err), Retryable: true}
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
+	resp, respBody, err := c.send(ctx, req, 1)
 	if err != nil {
-		return nil, &ClientError{Code: "READ_ERROR", Message: fmt.Sprintf("failed to read response: %v", err)}
+		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
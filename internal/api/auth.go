@@ -1,6 +1,9 @@
 package api
 
-import "context"
+import (
+	"context"
+	"net/url"
+)
 
 type User struct {
 	ID             int    `json:"id"`
@@ -22,3 +25,38 @@ func (c *Client) GetUser(ctx context.Context) (*UserResponse, error) {
 	}
 	return &resp, nil
 }
+
+// AuthorizeURL is the ClickUp page where users approve an OAuth app.
+const AuthorizeURL = "https://app.clickup.com/api"
+
+// OAuthAuthorizeURL returns the URL that asks the user to authorize clientID.
+// ClickUp redirects to redirectURI with a code query parameter afterwards.
+func OAuthAuthorizeURL(clientID, redirectURI, state string) string {
+	q := url.Values{}
+	q.Set("client_id", clientID)
+	q.Set("redirect_uri", redirectURI)
+	if state != "" {
+		q.Set("state", state)
+	}
+	return AuthorizeURL + "?" + q.Encode()
+}
+
+type AccessTokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Code         string `json:"code"`
+}
+
+type AccessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+// GetAccessToken exchanges an OAuth authorization code for an access token.
+// It needs no token on the client.
+func (c *Client) GetAccessToken(ctx context.Context, req *AccessTokenRequest) (*AccessTokenResponse, error) {
+	var resp AccessTokenResponse
+	if err := c.Do(ctx, "POST", "/v2/oauth/token", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -73,3 +74,61 @@ func TestGetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v2/oauth/token" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no Authorization header, got %q", auth)
+		}
+		var body AccessTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.ClientID != "cid" || body.ClientSecret != "secret" || body.Code != "abc" {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		_, _ = w.Write([]byte(`{"access_token":"oauth_token_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("")
+	client.MaxRetries = 0
+	client.BaseURL = server.URL
+
+	resp, err := client.GetAccessToken(context.Background(), &AccessTokenRequest{ClientID: "cid", ClientSecret: "secret", Code: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.AccessToken != "oauth_token_1" {
+		t.Errorf("unexpected token %q", resp.AccessToken)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer oauth_token_1" {
+			t.Errorf("expected Bearer authorization, got %q", auth)
+		}
+		_, _ = w.Write([]byte(`{"user":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("oauth_token_1")
+	client.TokenType = TokenTypeBearer
+	client.MaxRetries = 0
+	client.BaseURL = server.URL
+	if _, err := client.GetUser(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOAuthAuthorizeURL(t *testing.T) {
+	got := OAuthAuthorizeURL("cid", "http://127.0.0.1:8974/callback", "xyz")
+	want := "https://app.clickup.com/api?client_id=cid&redirect_uri=http%3A%2F%2F127.0.0.1%3A8974%2Fcallback&state=xyz"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
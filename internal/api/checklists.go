@@ -6,14 +6,14 @@ import (
 )
 
 type ChecklistItem struct {
-	ID          string        `json:"id"`
-	Name        string        `json:"name"`
-	OrderIndex  interface{}   `json:"orderindex"`
-	Assignee    interface{}   `json:"assignee"`
-	Resolved    bool          `json:"resolved"`
-	Parent      interface{}   `json:"parent"`
-	DateCreated string        `json:"date_created,omitempty"`
-	Children    []interface{} `json:"children,omitempty"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	OrderIndex  FlexFloat       `json:"orderindex"`
+	Assignee    *User           `json:"assignee"`
+	Resolved    bool            `json:"resolved"`
+	Parent      *string         `json:"parent"`
+	DateCreated string          `json:"date_created,omitempty"`
+	Children    []ChecklistItem `json:"children,omitempty"`
 }
 
 // Checklist is defined in tasks.go
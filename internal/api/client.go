@@ -14,6 +14,10 @@ import (
 
 const DefaultBaseURL = "https://api.clickup.com/api"
 
+// DefaultUserAgent is the User-Agent NewClient sends unless WithUserAgent
+// says otherwise.
+const DefaultUserAgent = "clickup-cli"
+
 const (
 	defaultMaxRetries    = 3
 	defaultRetryBaseWait = 1 * time.Second
@@ -24,6 +28,7 @@ const (
 type ClientInterface interface {
 	// Auth
 	GetUser(ctx context.Context) (*UserResponse, error)
+	GetAccessToken(ctx context.Context, req *AccessTokenRequest) (*AccessTokenResponse, error)
 
 	// Workspaces
 	ListWorkspaces(ctx context.Context) (*WorkspacesResponse, error)
@@ -36,15 +41,15 @@ type ClientInterface interface {
 	DeleteSpace(ctx context.Context, spaceID string) error
 
 	// Folders
-	ListFolders(ctx context.Context, spaceID string) (*FoldersResponse, error)
+	ListFolders(ctx context.Context, spaceID string, opts ...ListFoldersOptions) (*FoldersResponse, error)
 	GetFolder(ctx context.Context, folderID string) (*Folder, error)
 	CreateFolder(ctx context.Context, spaceID string, req *CreateFolderRequest) (*Folder, error)
 	UpdateFolder(ctx context.Context, folderID string, req *UpdateFolderRequest) (*Folder, error)
 	DeleteFolder(ctx context.Context, folderID string) error
 
 	// Lists
-	ListLists(ctx context.Context, folderID string) (*ListsResponse, error)
-	ListFolderlessLists(ctx context.Context, spaceID string) (*ListsResponse, error)
+	ListLists(ctx context.Context, folderID string, opts ...ListListsOptions) (*ListsResponse, error)
+	ListFolderlessLists(ctx context.Context, spaceID string, opts ...ListListsOptions) (*ListsResponse, error)
 	GetList(ctx context.Context, listID string) (*List, error)
 	CreateList(ctx context.Context, folderID string, req *CreateListRequest) (*List, error)
 	CreateFolderlessList(ctx context.Context, spaceID string, req *CreateListRequest) (*List, error)
@@ -93,7 +98,7 @@ type ClientInterface interface {
 
 	// Docs (v3)
 	CreateDoc(ctx context.Context, workspaceID string, req *CreateDocRequest) (*Doc, error)
-	SearchDocs(ctx context.Context, workspaceID string) (*DocsResponse, error)
+	SearchDocs(ctx context.Context, workspaceID string, opts ...SearchDocsOptions) (*DocsResponse, error)
 	GetDoc(ctx context.Context, workspaceID, docID string) (*Doc, error)
 	CreatePage(ctx context.Context, workspaceID, docID string, req *CreatePageRequest) (*DocPage, error)
 	GetPage(ctx context.Context, workspaceID, docID, pageID string) (*DocPage, error)
@@ -228,11 +233,43 @@ type ClientInterface interface {
 
 // Client implements ClientInterface using HTTP requests to the ClickUp API.
 type Client struct {
-	BaseURL       string
-	Token         string
+	BaseURL string
+	Token   string
+	// TokenType prefixes Token in the Authorization header. Personal API
+	// tokens are sent as-is (empty TokenType); OAuth access tokens use
+	// TokenTypeBearer.
+	TokenType     string
 	HTTPClient    *http.Client
 	MaxRetries    int
 	RetryBaseWait time.Duration
+	// RateLimiter throttles requests before they are sent; nil disables
+	// client-side throttling. NewClient shares one limiter per token.
+	RateLimiter *RateLimiter
+	// DryRun stops requests other than GET from being sent: the operation
+	// returns a *DryRunError describing the request instead.
+	DryRun bool
+	// Logger, if set, sees every request attempt and its response.
+	Logger Logger
+	// Middleware wraps the transport of every request, JSON and uploads
+	// alike; the first entry is outermost.
+	Middleware []Middleware
+	// UserAgent is sent as the User-Agent header unless it is empty.
+	UserAgent string
+}
+
+// TokenTypeBearer is the TokenType for OAuth access tokens.
+const TokenTypeBearer = "Bearer"
+
+// setAuth sets the Authorization header for the client's token, if any.
+func (c *Client) setAuth(req *http.Request) {
+	if c.Token == "" {
+		return
+	}
+	if c.TokenType != "" {
+		req.Header.Set("Authorization", c.TokenType+" "+c.Token)
+		return
+	}
+	req.Header.Set("Authorization", c.Token)
 }
 
 var _ ClientInterface = (*Client)(nil)
@@ -246,12 +283,15 @@ type APIError struct {
 // ClientError is a typed error returned by all API methods.
 // StatusCode is the HTTP status (0 for non-HTTP errors).
 // Code is a machine-readable error code (e.g. UNAUTHORIZED, RATE_LIMITED).
-// Retryable indicates whether the caller should retry.
+// Retryable indicates whether the caller should retry, after RetryAfter if set.
 type ClientError struct {
 	StatusCode int
 	Code       string
 	Message    string
 	Retryable  bool
+	// RetryAfter is how long a rate-limited (429) response asked to wait,
+	// from Retry-After or X-RateLimit-Reset; 0 if it did not say.
+	RetryAfter time.Duration
 }
 
 func (e *ClientError) Error() string {
@@ -261,8 +301,10 @@ func (e *ClientError) Error() string {
 	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
 }
 
-func NewClient(token string) *Client {
-	return &Client{
+// NewClient returns a client for token with the default base URL, timeout
+// and retries, changed by opts.
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
 		BaseURL: DefaultBaseURL,
 		Token:   token,
 		HTTPClient: &http.Client{
@@ -270,10 +312,18 @@ func NewClient(token string) *Client {
 		},
 		MaxRetries:    defaultMaxRetries,
 		RetryBaseWait: defaultRetryBaseWait,
+		RateLimiter:   SharedRateLimiter(token),
+		UserAgent:     DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Do executes an HTTP request with automatic retry for 429 and 5xx responses.
+// A 429 that reports when the limit resets is retried exactly then; other
+// failures back off exponentially.
 func (c *Client) Do(ctx context.Context, method, path string, body, result interface{}) error {
 	var bodyBytes []byte
 	if body != nil {
@@ -284,6 +334,10 @@ func (c *Client) Do(ctx context.Context, method, path string, body, result inter
 		}
 	}
 
+	if err := c.dryRun(method, path, bodyBytes); err != nil {
+		return err
+	}
+
 	var lastErr error
 	maxAttempts := c.MaxRetries + 1
 	if maxAttempts < 1 {
@@ -293,6 +347,9 @@ func (c *Client) Do(ctx context.Context, method, path string, body, result inter
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		if attempt > 0 {
 			wait := c.retryWait(attempt)
+			if clientErr, ok := lastErr.(*ClientError); ok && clientErr.RetryAfter > 0 {
+				wait = clientErr.RetryAfter
+			}
 			select {
 			case <-ctx.Done():
 				return &ClientError{Code: "CANCELLED", Message: "request cancelled during retry wait"}
@@ -300,7 +357,7 @@ func (c *Client) Do(ctx context.Context, method, path string, body, result inter
 			}
 		}
 
-		err := c.doOnce(ctx, method, path, bodyBytes, result)
+		err := c.doOnce(ctx, method, path, bodyBytes, result, attempt+1)
 		if err == nil {
 			return nil
 		}
@@ -317,8 +374,8 @@ func (c *Client) Do(ctx context.Context, method, path string, body, result inter
 	return lastErr
 }
 
-// doOnce performs a single HTTP request attempt.
-func (c *Client) doOnce(ctx context.Context, method, path string, bodyBytes []byte, result interface{}) error {
+// doOnce performs a single HTTP request attempt; attempt counts from 1.
+func (c *Client) doOnce(ctx context.Context, method, path string, bodyBytes []byte, result interface{}, attempt int) error {
 	var reqBody io.Reader
 	if bodyBytes != nil {
 		reqBody = bytes.NewReader(bodyBytes)
@@ -330,35 +387,30 @@ func (c *Client) doOnce(ctx context.Context, method, path string, bodyBytes []by
 		return &ClientError{Code: "REQUEST_ERROR", Message: fmt.Sprintf("failed to create request: %v", err)}
 	}
 
-	req.Header.Set("Authorization", c.Token)
+	c.setAuth(req)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, respBody, err := c.send(ctx, req, attempt)
 	if err != nil {
-		if ctx.Err() != nil {
-			return &ClientError{Code: "CANCELLED", Message: "request cancelled"}
-		}
-		return &ClientError{Code: "NETWORK_ERROR", Message: fmt.Sprintf("request failed: %v", err), Retryable: true}
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return &ClientError{Code: "READ_ERROR", Message: fmt.Sprintf("failed to read response: %v", err)}
+		return err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		code := errorCodeFromStatus(resp.StatusCode)
 		retryable := resp.StatusCode == 429 || resp.StatusCode >= 500
+		var retryAfter time.Duration
+		if resp.StatusCode == 429 {
+			retryAfter = RetryAfter(resp.Header, time.Now())
+		}
 		var apiErr APIError
 		if json.Unmarshal(respBody, &apiErr) == nil && (apiErr.Err != "" || apiErr.Message != "") {
 			msg := apiErr.Err
 			if msg == "" {
 				msg = apiErr.Message
 			}
-			return &ClientError{StatusCode: resp.StatusCode, Code: code, Message: msg, Retryable: retryable}
+			return &ClientError{StatusCode: resp.StatusCode, Code: code, Message: msg, Retryable: retryable, RetryAfter: retryAfter}
 		}
-		return &ClientError{StatusCode: resp.StatusCode, Code: code, Message: fmt.Sprintf("API returned status %d", resp.StatusCode), Retryable: retryable}
+		return &ClientError{StatusCode: resp.StatusCode, Code: code, Message: fmt.Sprintf("API returned status %d", resp.StatusCode), Retryable: retryable, RetryAfter: retryAfter}
 	}
 
 	if result != nil && len(respBody) > 0 {
@@ -370,6 +422,38 @@ func (c *Client) doOnce(ctx context.Context, method, path string, bodyBytes []by
 	return nil
 }
 
+// send sends req through the middleware chain once the rate limiter allows,
+// and returns the response with its body read. attempt counts from 1 and is
+// available to middleware through RequestAttempt.
+func (c *Client) send(ctx context.Context, req *http.Request, attempt int) (*http.Response, []byte, error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt))
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, &ClientError{Code: "CANCELLED", Message: "request cancelled"}
+		}
+		return nil, nil, &ClientError{Code: "NETWORK_ERROR", Message: fmt.Sprintf("request failed: %v", err), Retryable: true}
+	}
+	defer resp.Body.Close()
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(resp.StatusCode, resp.Header)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, &ClientError{Code: "READ_ERROR", Message: fmt.Sprintf("failed to read response: %v", err)}
+	}
+	return resp, respBody, nil
+}
+
 // retryWait computes wait duration with exponential backoff + jitter.
 func (c *Client) retryWait(attempt int) time.Duration {
 	base := c.RetryBaseWait
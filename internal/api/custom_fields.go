@@ -12,7 +12,14 @@ type CustomFieldsResponse struct {
 }
 
 type SetCustomFieldRequest struct {
-	Value interface{} `json:"value"`
+	Value        interface{}              `json:"value"`
+	ValueOptions *CustomFieldValueOptions `json:"value_options,omitempty"`
+}
+
+// CustomFieldValueOptions qualifies a value. Time marks a date field's
+// value as including the time of day.
+type CustomFieldValueOptions struct {
+	Time bool `json:"time"`
 }
 
 func (c *Client) GetListCustomFields(ctx context.Context, listID string) (*CustomFieldsResponse, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // Doc represents a ClickUp Doc (v3 API).
@@ -11,15 +12,16 @@ type Doc struct {
 	ID          string      `json:"id"`
 	Name        string      `json:"name,omitempty"`
 	WorkspaceID json.Number `json:"workspace_id,omitempty"`
-	Parent      interface{} `json:"parent,omitempty"`
-	Creator     interface{} `json:"creator,omitempty"`
+	Parent      *DocParent  `json:"parent,omitempty"`
+	Creator     json.Number `json:"creator,omitempty"`
 	DateCreated json.Number `json:"date_created,omitempty"`
 	Deleted     bool        `json:"deleted,omitempty"`
 	Visibility  string      `json:"visibility,omitempty"`
 }
 
 type DocsResponse struct {
-	Docs []Doc `json:"docs"`
+	Docs       []Doc  `json:"docs"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type CreateDocRequest struct {
@@ -68,6 +70,8 @@ type EditPageRequest struct {
 
 type SearchDocsOptions struct {
 	WorkspaceID string
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
 }
 
 func (c *Client) CreateDoc(ctx context.Context, workspaceID string, req *CreateDocRequest) (*Doc, error) {
@@ -78,9 +82,15 @@ func (c *Client) CreateDoc(ctx context.Context, workspaceID string, req *CreateD
 	return &resp, nil
 }
 
-func (c *Client) SearchDocs(ctx context.Context, workspaceID string) (*DocsResponse, error) {
+// SearchDocs returns a page of the workspace's docs. The next page is
+// fetched with the response's NextCursor as opts.Cursor.
+func (c *Client) SearchDocs(ctx context.Context, workspaceID string, opts ...SearchDocsOptions) (*DocsResponse, error) {
+	path := fmt.Sprintf("/v3/workspaces/%s/docs", workspaceID)
+	if len(opts) > 0 && opts[0].Cursor != "" {
+		path += "?cursor=" + url.QueryEscape(opts[0].Cursor)
+	}
 	var resp DocsResponse
-	if err := c.Do(ctx, "GET", fmt.Sprintf("/v3/workspaces/%s/docs", workspaceID), nil, &resp); err != nil {
+	if err := c.Do(ctx, "GET", path, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
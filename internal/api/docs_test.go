@@ -51,6 +51,24 @@ func TestSearchDocs(t *testing.T) {
 	}
 }
 
+func TestSearchDocsCursor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("cursor"); got != "next/1" {
+			t.Errorf("cursor = %q", got)
+		}
+		_, _ = w.Write([]byte(`{"docs":[{"id":"d2"}],"next_cursor":"next/2"}`))
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL, Token: "test", HTTPClient: srv.Client()}
+	resp, err := c.SearchDocs(context.Background(), "w1", SearchDocsOptions{Cursor: "next/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NextCursor != "next/2" {
+		t.Errorf("next cursor = %q", resp.NextCursor)
+	}
+}
+
 func TestGetDoc(t *testing.T) {
 	ctx := context.Background()
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
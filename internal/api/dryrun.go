@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DryRunRequest describes a request that a dry-run client did not send.
+type DryRunRequest struct {
+	Method string `json:"method"`
+	// Path is relative to the client's BaseURL, without the query.
+	Path  string          `json:"path"`
+	Query url.Values      `json:"query,omitempty"`
+	Body  json.RawMessage `json:"body,omitempty"`
+	// File is the upload of a multipart request, which has no JSON body.
+	File *DryRunFile `json:"file,omitempty"`
+}
+
+// DryRunFile is a file a dry-run client would have uploaded.
+type DryRunFile struct {
+	Field string `json:"field"`
+	Name  string `json:"name"`
+	Size  int64  `json:"size"`
+}
+
+// DryRunError is returned instead of sending a request that would change
+// something (anything but GET) when Client.DryRun is set. An operation that
+// sends several requests stops at the first such request, which Request
+// describes.
+type DryRunError struct {
+	Request DryRunRequest
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: %s %s was not sent", e.Request.Method, e.Request.Path)
+}
+
+// dryRun reports the request as a DryRunError if the client is in dry-run
+// mode and method would change something, and returns nil otherwise.
+func (c *Client) dryRun(method, path string, body []byte) *DryRunError {
+	if !c.DryRun || method == "GET" {
+		return nil
+	}
+	path, rawQuery, _ := strings.Cut(path, "?")
+	req := DryRunRequest{Method: method, Path: path, Body: body}
+	if q, err := url.ParseQuery(rawQuery); err == nil && len(q) > 0 {
+		req.Query = q
+	}
+	return &DryRunError{Request: req}
+}
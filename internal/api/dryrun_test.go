@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"t1","name":"Task"}`))
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL, HTTPClient: srv.Client(), DryRun: true}
+
+	if _, err := c.GetTask(ctx, "t1", GetTaskOptions{}); err != nil {
+		t.Fatalf("expected reads to be sent, got %v", err)
+	}
+
+	_, err := c.UpdateTask(ctx, "t1", &UpdateTaskRequest{Name: StringPtr("Renamed")}, UpdateTaskOptions{CustomTaskIDs: true, TeamID: "9"})
+	var dr *DryRunError
+	if !errors.As(err, &dr) {
+		t.Fatalf("expected a DryRunError, got %v", err)
+	}
+	if dr.Request.Method != "PUT" || dr.Request.Path != "/v2/task/t1" {
+		t.Errorf("got %s %s", dr.Request.Method, dr.Request.Path)
+	}
+	if dr.Request.Query.Get("custom_task_ids") != "true" || dr.Request.Query.Get("team_id") != "9" {
+		t.Errorf("expected the query, got %v", dr.Request.Query)
+	}
+	if string(dr.Request.Body) != `{"name":"Renamed"}` {
+		t.Errorf("got body %s", dr.Request.Body)
+	}
+
+	if err := c.DeleteTask(ctx, "t1"); !errors.As(err, &dr) || dr.Request.Method != "DELETE" || dr.Request.Query != nil || dr.Request.Body != nil {
+		t.Errorf("expected a bare DELETE, got %v", err)
+	}
+
+	if len(calls) != 1 || calls[0] != "GET /v2/task/t1" {
+		t.Errorf("expected only the read to reach the server, got %v", calls)
+	}
+}
+
+func TestDryRunAttachment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+	file := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{BaseURL: srv.URL, HTTPClient: srv.Client(), DryRun: true}
+
+	_, err := c.CreateTaskAttachment(context.Background(), "t1", file)
+	var dr *DryRunError
+	if !errors.As(err, &dr) {
+		t.Fatalf("expected a DryRunError, got %v", err)
+	}
+	if dr.Request.Method != "POST" || dr.Request.Path != "/v2/task/t1/attachment" {
+		t.Errorf("got %s %s", dr.Request.Method, dr.Request.Path)
+	}
+	if f := dr.Request.File; f == nil || f.Field != "attachment" || f.Name != "report.txt" || f.Size != 5 {
+		t.Errorf("got file %+v", f)
+	}
+}
@@ -23,9 +23,16 @@ type FoldersResponse struct {
 	Folders []Folder `json:"folders"`
 }
 
-func (c *Client) ListFolders(ctx context.Context, spaceID string) (*FoldersResponse, error) {
+type ListFoldersOptions struct {
+	// Archived lists the space's archived folders instead of its active
+	// ones.
+	Archived bool
+}
+
+func (c *Client) ListFolders(ctx context.Context, spaceID string, opts ...ListFoldersOptions) (*FoldersResponse, error) {
+	archived := len(opts) > 0 && opts[0].Archived
 	var resp FoldersResponse
-	if err := c.Do(ctx, "GET", fmt.Sprintf("/v2/space/%s/folder?archived=false", spaceID), nil, &resp); err != nil {
+	if err := c.Do(ctx, "GET", fmt.Sprintf("/v2/space/%s/folder?archived=%t", spaceID, archived), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -30,23 +30,31 @@ type List struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"space"`
+	Statuses []TaskStatus `json:"statuses,omitempty"`
 }
 
 type ListsResponse struct {
 	Lists []List `json:"lists"`
 }
 
-func (c *Client) ListLists(ctx context.Context, folderID string) (*ListsResponse, error) {
+type ListListsOptions struct {
+	// Archived lists the archived lists instead of the active ones.
+	Archived bool
+}
+
+func (c *Client) ListLists(ctx context.Context, folderID string, opts ...ListListsOptions) (*ListsResponse, error) {
+	archived := len(opts) > 0 && opts[0].Archived
 	var resp ListsResponse
-	if err := c.Do(ctx, "GET", fmt.Sprintf("/v2/folder/%s/list?archived=false", folderID), nil, &resp); err != nil {
+	if err := c.Do(ctx, "GET", fmt.Sprintf("/v2/folder/%s/list?archived=%t", folderID, archived), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-func (c *Client) ListFolderlessLists(ctx context.Context, spaceID string) (*ListsResponse, error) {
+func (c *Client) ListFolderlessLists(ctx context.Context, spaceID string, opts ...ListListsOptions) (*ListsResponse, error) {
+	archived := len(opts) > 0 && opts[0].Archived
 	var resp ListsResponse
-	if err := c.Do(ctx, "GET", fmt.Sprintf("/v2/space/%s/list?archived=false", spaceID), nil, &resp); err != nil {
+	if err := c.Do(ctx, "GET", fmt.Sprintf("/v2/space/%s/list?archived=%t", spaceID, archived), nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
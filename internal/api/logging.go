@@ -0,0 +1,320 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger receives every HTTP exchange the client makes, including retried
+// attempts and requests that got no response. Set Client.Logger to use one.
+type Logger interface {
+	LogExchange(e *Exchange)
+}
+
+// Exchange is one request attempt and its response. Credentials in headers
+// and JSON bodies are already redacted; non-JSON bodies are replaced by a
+// size note.
+type Exchange struct {
+	Started  time.Time
+	Duration time.Duration
+	// Attempt counts from 1; higher values are retries.
+	Attempt        int
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	// Err is why no response was read, if none was.
+	Err error
+}
+
+// Redacted replaces values of headers and JSON body fields that carry
+// credentials.
+const Redacted = "[REDACTED]"
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+	"X-Signature":   true,
+}
+
+var sensitiveFields = map[string]bool{
+	"access_token":  true,
+	"client_secret": true,
+	"code":          true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// newExchange builds the Exchange for a request and its response or error,
+// redacting what it copies.
+func newExchange(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, err error, started time.Time, attempt int) *Exchange {
+	e := &Exchange{
+		Started:       started,
+		Duration:      time.Since(started),
+		Attempt:       attempt,
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: redactHeader(req.Header),
+		RequestBody:   redactBody(req.Header, reqBody),
+		Err:           err,
+	}
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		e.ResponseHeader = redactHeader(resp.Header)
+		e.ResponseBody = redactBody(resp.Header, respBody)
+	}
+	return e
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for name := range out {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			out[name] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+// redactBody returns a JSON body with credential fields redacted, at any
+// depth, or a size note for other bodies.
+func redactBody(h http.Header, body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	if !strings.HasPrefix(h.Get("Content-Type"), "application/json") {
+		return []byte(fmt.Sprintf("[%d bytes of %s]", len(body), h.Get("Content-Type")))
+	}
+	var v interface{}
+	if json.Unmarshal(body, &v) != nil {
+		return body
+	}
+	if !redactValue(v) {
+		return body
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// redactValue redacts credential fields in a decoded JSON value in place and
+// reports whether it changed anything.
+func redactValue(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if sensitiveFields[strings.ToLower(k)] {
+				if _, isString := child.(string); isString {
+					v[k] = Redacted
+					changed = true
+					continue
+				}
+			}
+			changed = redactValue(child) || changed
+		}
+	case []interface{}:
+		for _, child := range v {
+			changed = redactValue(child) || changed
+		}
+	}
+	return changed
+}
+
+// Loggers sends each exchange to every logger in turn.
+type Loggers []Logger
+
+func (ls Loggers) LogExchange(e *Exchange) {
+	for _, l := range ls {
+		l.LogExchange(e)
+	}
+}
+
+// TextLogger writes exchanges as readable text, such as to stderr for
+// --verbose: "> " lines for the request and "< " lines for the response.
+type TextLogger struct {
+	W io.Writer
+	// MaxBody caps how much of each body is written; 0 writes all of it.
+	MaxBody int
+
+	mu sync.Mutex
+}
+
+// NewTextLogger returns a TextLogger for w that truncates bodies at 4 KB.
+func NewTextLogger(w io.Writer) *TextLogger {
+	return &TextLogger{W: w, MaxBody: 4096}
+}
+
+func (l *TextLogger) LogExchange(e *Exchange) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "> %s %s", e.Method, e.URL)
+	if e.Attempt > 1 {
+		fmt.Fprintf(&b, " (retry %d)", e.Attempt-1)
+	}
+	b.WriteString("\n")
+	writeHeaders(&b, "> ", e.RequestHeader)
+	l.writeBody(&b, "> ", e.RequestBody)
+	ms := e.Duration.Milliseconds()
+	if e.Err != nil {
+		fmt.Fprintf(&b, "< error after %dms: %v\n", ms, e.Err)
+	} else {
+		fmt.Fprintf(&b, "< %d %s in %dms\n", e.StatusCode, http.StatusText(e.StatusCode), ms)
+		writeHeaders(&b, "< ", e.ResponseHeader)
+		l.writeBody(&b, "< ", e.ResponseBody)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.W, b.String())
+}
+
+func writeHeaders(b *strings.Builder, prefix string, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range h[name] {
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, name, v)
+		}
+	}
+}
+
+func (l *TextLogger) writeBody(b *strings.Builder, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	s := string(body)
+	if l.MaxBody > 0 && len(s) > l.MaxBody {
+		s = s[:l.MaxBody] + fmt.Sprintf("... (%d more bytes)", len(body)-l.MaxBody)
+	}
+	fmt.Fprintf(b, "%s\n%s%s\n", strings.TrimSpace(prefix), prefix, s)
+}
+
+// HARLogger records exchanges in a HAR-like JSON file, rewritten after
+// every exchange so it is complete even if the command fails.
+type HARLogger struct {
+	Path string
+	// Version is the program version recorded as the log's creator.
+	Version string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewHARLogger returns a HARLogger writing to path, created by the given
+// program version.
+func NewHARLogger(path, version string) *HARLogger {
+	return &HARLogger{Path: path, Version: version}
+}
+
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Attempt         int         `json:"_attempt"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method   string       `json:"method"`
+	URL      string       `json:"url"`
+	Headers  []harNameVal `json:"headers"`
+	PostData *harContent  `json:"postData,omitempty"`
+}
+
+type harResponse struct {
+	Status     int          `json:"status"`
+	StatusText string       `json:"statusText"`
+	Headers    []harNameVal `json:"headers"`
+	Content    harContent   `json:"content"`
+}
+
+type harNameVal struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+func harHeaders(h http.Header) []harNameVal {
+	out := []harNameVal{}
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range h[name] {
+			out = append(out, harNameVal{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func (l *HARLogger) LogExchange(e *Exchange) {
+	entry := harEntry{
+		StartedDateTime: e.Started.UTC().Format(time.RFC3339Nano),
+		Time:            e.Duration.Milliseconds(),
+		Request:         harRequest{Method: e.Method, URL: e.URL, Headers: harHeaders(e.RequestHeader)},
+		Response: harResponse{
+			Status:     e.StatusCode,
+			StatusText: http.StatusText(e.StatusCode),
+			Headers:    harHeaders(e.ResponseHeader),
+			Content:    harContent{Size: len(e.ResponseBody), MimeType: e.ResponseHeader.Get("Content-Type"), Text: string(e.ResponseBody)},
+		},
+		Attempt: e.Attempt,
+	}
+	if len(e.RequestBody) > 0 {
+		entry.Request.PostData = &harContent{Size: len(e.RequestBody), MimeType: e.RequestHeader.Get("Content-Type"), Text: string(e.RequestBody)}
+	}
+	if e.Err != nil {
+		entry.Error = e.Err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	var har harLog
+	har.Log.Version = "1.2"
+	har.Log.Creator = harCreator{Name: "clickup-cli", Version: l.Version}
+	har.Log.Entries = l.entries
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return
+	}
+	// The log may hold workspace data, so it is private like the config file.
+	_ = os.WriteFile(l.Path, data, 0o600)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingLogger struct {
+	mu        sync.Mutex
+	exchanges []*Exchange
+}
+
+func (l *recordingLogger) LogExchange(e *Exchange) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.exchanges = append(l.exchanges, e)
+}
+
+func TestClientLogsExchanges(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"err":"bad gateway"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"w1","secret":"s3cr3t","endpoint":"https://example.com"}`))
+	}))
+	defer srv.Close()
+	logger := &recordingLogger{}
+	c := &Client{BaseURL: srv.URL, Token: "pk_secret", HTTPClient: srv.Client(), MaxRetries: 1, RetryBaseWait: time.Millisecond, Logger: logger}
+
+	body := map[string]interface{}{"endpoint": "https://example.com", "auth": map[string]string{"token": "t0ken"}}
+	if err := c.Do(context.Background(), "POST", "/v2/team/1/webhook", body, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.exchanges) != 2 {
+		t.Fatalf("expected both attempts to be logged, got %d", len(logger.exchanges))
+	}
+	first, second := logger.exchanges[0], logger.exchanges[1]
+	if first.Attempt != 1 || first.StatusCode != http.StatusBadGateway || second.Attempt != 2 || second.StatusCode != http.StatusOK {
+		t.Errorf("got attempts %d/%d with statuses %d/%d", first.Attempt, second.Attempt, first.StatusCode, second.StatusCode)
+	}
+	if first.Method != "POST" || first.URL != srv.URL+"/v2/team/1/webhook" {
+		t.Errorf("got %s %s", first.Method, first.URL)
+	}
+	if got := first.RequestHeader.Get("Authorization"); got != Redacted {
+		t.Errorf("expected the token to be redacted, got %q", got)
+	}
+	if strings.Contains(string(first.RequestBody), "t0ken") || !strings.Contains(string(first.RequestBody), "example.com") {
+		t.Errorf("expected only the nested token to be redacted, got %s", first.RequestBody)
+	}
+	if strings.Contains(string(second.ResponseBody), "s3cr3t") {
+		t.Errorf("expected the webhook secret to be redacted, got %s", second.ResponseBody)
+	}
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewTextLogger(&buf)
+	l.MaxBody = 10
+	l.LogExchange(&Exchange{
+		Attempt: 2, Method: "GET", URL: "https://api.clickup.com/api/v2/team", Duration: 42 * time.Millisecond,
+		RequestHeader: http.Header{"Authorization": {Redacted}},
+		StatusCode:    200, ResponseHeader: http.Header{"Content-Type": {"application/json"}},
+		ResponseBody: []byte(`{"teams":[{"id":"1"}]}`),
+	})
+	want := `> GET https://api.clickup.com/api/v2/team (retry 1)
+> Authorization: [REDACTED]
+< 200 OK in 42ms
+< Content-Type: application/json
+<
+< {"teams":[... (12 more bytes)
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestHARLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.har")
+	l := NewHARLogger(path, "1.2.3")
+	l.LogExchange(&Exchange{Attempt: 1, Method: "PUT", URL: "https://x/v2/task/1",
+		RequestHeader: http.Header{"Content-Type": {"application/json"}}, RequestBody: []byte(`{"name":"a"}`),
+		StatusCode: 200, ResponseBody: []byte(`{}`)})
+	l.LogExchange(&Exchange{Attempt: 1, Method: "GET", URL: "https://x/v2/team", Err: context.DeadlineExceeded})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var har struct {
+		Log struct {
+			Creator struct{ Version string }
+			Entries []struct {
+				Request struct {
+					Method   string
+					PostData *struct{ Text string }
+				}
+				Response struct{ Status int }
+				Error    string `json:"_error"`
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatal(err)
+	}
+	if har.Log.Creator.Version != "1.2.3" || len(har.Log.Entries) != 2 {
+		t.Fatalf("got %s", data)
+	}
+	if e := har.Log.Entries[0]; e.Request.Method != "PUT" || e.Request.PostData == nil || e.Request.PostData.Text != `{"name":"a"}` || e.Response.Status != 200 {
+		t.Errorf("unexpected first entry: %+v", e)
+	}
+	if e := har.Log.Entries[1]; e.Error == "" || e.Request.PostData != nil {
+		t.Errorf("expected a failed GET, got %+v", e)
+	}
+}
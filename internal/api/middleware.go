@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the transport that sends each request attempt, for
+// logging, metrics, extra headers, proxies or recording. Requests reaching
+// a middleware already carry auth and content headers; rate limiting and
+// retries happen outside the chain, so each attempt passes through it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type attemptKey struct{}
+
+// RequestAttempt reports which attempt at a request ctx belongs to, counting
+// from 1, or 0 for a context the client did not send.
+func RequestAttempt(ctx context.Context) int {
+	n, _ := ctx.Value(attemptKey{}).(int)
+	return n
+}
+
+// Logging returns middleware that reports every exchange to l, credentials
+// redacted. The client installs it innermost when Client.Logger is set, so
+// l sees the request as sent, headers added by other middleware included.
+func Logging(l Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var reqBody []byte
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					reqBody, _ = io.ReadAll(body)
+					body.Close()
+				}
+			}
+			attempt := RequestAttempt(req.Context())
+			started := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				l.LogExchange(newExchange(req, reqBody, nil, nil, err, started, attempt))
+				return nil, err
+			}
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			l.LogExchange(newExchange(req, reqBody, resp, respBody, err, started, attempt))
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			return resp, nil
+		})
+	}
+}
+
+// httpClient returns HTTPClient with its transport wrapped in the
+// middleware chain: Middleware[0] outermost, then the rest in order, then
+// the logger.
+func (c *Client) httpClient() *http.Client {
+	if len(c.Middleware) == 0 && c.Logger == nil {
+		return c.HTTPClient
+	}
+	rt := c.HTTPClient.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if c.Logger != nil {
+		rt = Logging(c.Logger)(rt)
+	}
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		rt = c.Middleware[i](rt)
+	}
+	hc := *c.HTTPClient
+	hc.Transport = rt
+	return &hc
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.Header.Get("X-Trace"); got != "a,b" {
+			t.Errorf("expected headers from both middleware in order, got %q", got)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"teams":[]}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	var attempts []int
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				if name == "a" {
+					attempts = append(attempts, RequestAttempt(req.Context()))
+				}
+				req = req.Clone(req.Context())
+				req.Header.Set("X-Trace", strings.TrimPrefix(req.Header.Get("X-Trace")+","+name, ","))
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	logger := &recordingLogger{}
+	c := NewClient("pk_test", WithMiddleware(tag("a")), WithMiddleware(tag("b")), WithLogger(logger))
+	c.BaseURL = srv.URL
+	c.RateLimiter = nil
+	c.RetryBaseWait = time.Millisecond
+
+	if _, err := c.ListWorkspaces(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(order, "") != "abab" {
+		t.Errorf("expected a then b on each attempt, got %v", order)
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("expected attempts 1 and 2, got %v", attempts)
+	}
+	if len(logger.exchanges) != 2 || logger.exchanges[1].RequestHeader.Get("X-Trace") != "a,b" {
+		t.Errorf("expected the logger to see the request as sent, got %+v", logger.exchanges)
+	}
+	if RequestAttempt(context.Background()) != 0 {
+		t.Error("expected no attempt outside the client")
+	}
+}
+
+func TestMiddlewareAttachment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("expected the middleware header on the upload")
+		}
+		_, _ = w.Write([]byte(`{"id":"att1"}`))
+	}))
+	defer srv.Close()
+	file := filepath.Join(t.TempDir(), "test.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var contentType string
+	header := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			contentType = req.Header.Get("Content-Type")
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Tenant", "acme")
+			return next.RoundTrip(req)
+		})
+	}
+	c := &Client{BaseURL: srv.URL, HTTPClient: srv.Client(), Middleware: []Middleware{header}}
+	if _, err := c.CreateTaskAttachment(context.Background(), "t1", file); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(contentType, "multipart/form-data") {
+		t.Errorf("expected the upload to pass through the middleware, got %q", contentType)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client built by NewClient.
+type Option func(*Client)
+
+// WithMiddleware appends middleware to the client's transport chain; the
+// first middleware given is outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.Middleware = append(c.Middleware, mw...)
+	}
+}
+
+// WithLogger reports every request attempt and its response to l.
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.Logger = l
+	}
+}
+
+// WithBaseURL sends requests to baseURL instead of DefaultBaseURL, such as
+// a local mock server or an enterprise proxy.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithTimeout limits each request attempt to d. The HTTP client is copied,
+// so one given with WithHTTPClient is not changed.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.HTTPClient
+		hc.Timeout = d
+		c.HTTPClient = &hc
+	}
+}
+
+// WithRetries sets how many times a 429 or 5xx response is retried; 0
+// disables retries.
+func WithRetries(max int) Option {
+	return func(c *Client) {
+		c.MaxRetries = max
+	}
+}
+
+// WithRetryBaseWait sets the wait before the first retry, doubled for each
+// one after.
+func WithRetryBaseWait(d time.Duration) Option {
+	return func(c *Client) {
+		c.RetryBaseWait = d
+	}
+}
+
+// WithUserAgent sends ua as the User-Agent header.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.UserAgent = ua
+	}
+}
+
+// WithHTTPClient sends requests with hc, keeping its transport and timeout.
+// Options apply in order, so a later WithTimeout changes a copy of hc.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.HTTPClient = hc
+		}
+	}
+}
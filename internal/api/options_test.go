@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	var agent string
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		agent = r.Header.Get("User-Agent")
+		if r.URL.Path != "/api/v2/team" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	hc := srv.Client()
+	c := NewClient("pk_test",
+		WithHTTPClient(hc),
+		WithBaseURL(srv.URL+"/api/"),
+		WithTimeout(5*time.Second),
+		WithRetries(1),
+		WithRetryBaseWait(time.Millisecond),
+		WithUserAgent("acme-bot/2.0"),
+	)
+	c.RateLimiter = nil
+
+	if c.HTTPClient.Timeout != 5*time.Second || hc.Timeout != 0 {
+		t.Errorf("expected the timeout on a copy of the given client, got %v and %v", c.HTTPClient.Timeout, hc.Timeout)
+	}
+	if c.HTTPClient.Transport != hc.Transport {
+		t.Error("expected the given client's transport to be kept")
+	}
+	if _, err := c.ListWorkspaces(context.Background()); err == nil {
+		t.Fatal("expected the 500 to fail")
+	}
+	if calls != 2 {
+		t.Errorf("expected 1 retry, got %d calls", calls)
+	}
+	if agent != "acme-bot/2.0" {
+		t.Errorf("got User-Agent %q", agent)
+	}
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	c := NewClient("pk_test")
+	if c.BaseURL != DefaultBaseURL || c.UserAgent != DefaultUserAgent || c.MaxRetries != defaultMaxRetries || c.HTTPClient.Timeout != 30*time.Second {
+		t.Errorf("unexpected defaults: %+v", c)
+	}
+}
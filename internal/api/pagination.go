@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+)
+
+// PageFunc fetches a single page of results. It returns the page's items and
+// whether the API reported it as the last page.
+type PageFunc[T any] func(ctx context.Context, page int) (items []T, last bool, err error)
+
+// PaginateOptions controls how many pages Paginate walks.
+type PaginateOptions struct {
+	// StartPage is the first page to fetch (ClickUp pages are zero-based).
+	StartPage int
+	// MaxPages caps the number of pages fetched. Zero means no limit.
+	MaxPages int
+}
+
+// Paginate fetches consecutive pages, passing each page's items to yield,
+// until a page is reported as last, a page comes back empty, or MaxPages is
+// reached. It reports whether the end of the results was reached; false means
+// the walk stopped at MaxPages and more pages may exist.
+func Paginate[T any](ctx context.Context, fetch PageFunc[T], opts PaginateOptions, yield func(items []T) error) (bool, error) {
+	for n, page := 0, opts.StartPage; opts.MaxPages <= 0 || n < opts.MaxPages; n, page = n+1, page+1 {
+		if err := ctx.Err(); err != nil {
+			return false, &ClientError{Code: "CANCELLED", Message: "pagination cancelled"}
+		}
+		items, last, err := fetch(ctx, page)
+		if err != nil {
+			return false, err
+		}
+		if len(items) > 0 {
+			if err := yield(items); err != nil {
+				return false, err
+			}
+		}
+		if last || len(items) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CollectPages walks all pages like Paginate and returns the merged items,
+// an empty slice rather than nil when there are none.
+func CollectPages[T any](ctx context.Context, fetch PageFunc[T], opts PaginateOptions) ([]T, bool, error) {
+	all := []T{}
+	complete, err := Paginate(ctx, fetch, opts, func(items []T) error {
+		all = append(all, items...)
+		return nil
+	})
+	return all, complete, err
+}
+
+// ListTasksPages returns a PageFunc over ListTasks. opts.Page is ignored.
+func ListTasksPages(c ClientInterface, listID string, opts *ListTasksOptions) PageFunc[Task] {
+	return func(ctx context.Context, page int) ([]Task, bool, error) {
+		o := ListTasksOptions{}
+		if opts != nil {
+			o = *opts
+		}
+		o.Page = page
+		resp, err := c.ListTasks(ctx, listID, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Tasks, resp.LastPage, nil
+	}
+}
+
+// SearchTasksPages returns a PageFunc over SearchTasks. opts.Page is ignored.
+func SearchTasksPages(c ClientInterface, teamID string, opts *SearchTasksOptions) PageFunc[Task] {
+	return func(ctx context.Context, page int) ([]Task, bool, error) {
+		o := SearchTasksOptions{}
+		if opts != nil {
+			o = *opts
+		}
+		o.Page = page
+		resp, err := c.SearchTasks(ctx, teamID, &o)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Tasks, resp.LastPage, nil
+	}
+}
+
+// ViewTasksPages returns a PageFunc over GetViewTasks.
+func ViewTasksPages(c ClientInterface, viewID string) PageFunc[interface{}] {
+	return func(ctx context.Context, page int) ([]interface{}, bool, error) {
+		resp, err := c.GetViewTasks(ctx, viewID, page)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Tasks, resp.LastPage, nil
+	}
+}
+
+// TaskTemplatesPages returns a PageFunc over GetTaskTemplates. The endpoint
+// has no last-page marker, so the walk ends on the first empty page.
+func TaskTemplatesPages(c ClientInterface, teamID string) PageFunc[TaskTemplate] {
+	return func(ctx context.Context, page int) ([]TaskTemplate, bool, error) {
+		resp, err := c.GetTaskTemplates(ctx, teamID, page)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Templates, false, nil
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+	// pages[i] holds the items returned for page i; lastAt marks the page
+	// reported as last (-1 for none).
+	tests := []struct {
+		name         string
+		pages        [][]int
+		lastAt       int
+		opts         PaginateOptions
+		want         []int
+		wantFetched  []int
+		wantComplete bool
+	}{
+		{
+			name:         "stops at last page",
+			pages:        [][]int{{1, 2}, {3, 4}, {5}},
+			lastAt:       2,
+			want:         []int{1, 2, 3, 4, 5},
+			wantFetched:  []int{0, 1, 2},
+			wantComplete: true,
+		},
+		{
+			name:         "stops at empty page",
+			pages:        [][]int{{1}, {2}, {}},
+			lastAt:       -1,
+			want:         []int{1, 2},
+			wantFetched:  []int{0, 1, 2},
+			wantComplete: true,
+		},
+		{
+			name:         "max pages cap",
+			pages:        [][]int{{1}, {2}, {3}, {4}},
+			lastAt:       3,
+			opts:         PaginateOptions{MaxPages: 2},
+			want:         []int{1, 2},
+			wantFetched:  []int{0, 1},
+			wantComplete: false,
+		},
+		{
+			name:         "cap on last page is complete",
+			pages:        [][]int{{1}, {2}},
+			lastAt:       1,
+			opts:         PaginateOptions{MaxPages: 2},
+			want:         []int{1, 2},
+			wantFetched:  []int{0, 1},
+			wantComplete: true,
+		},
+		{
+			name:         "start page",
+			pages:        [][]int{{1}, {2}, {3}},
+			lastAt:       2,
+			opts:         PaginateOptions{StartPage: 1},
+			want:         []int{2, 3},
+			wantFetched:  []int{1, 2},
+			wantComplete: true,
+		},
+		{
+			name:         "no results",
+			pages:        [][]int{{}},
+			lastAt:       0,
+			want:         []int{},
+			wantFetched:  []int{0},
+			wantComplete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []int
+			fetch := func(ctx context.Context, page int) ([]int, bool, error) {
+				fetched = append(fetched, page)
+				if page >= len(tt.pages) {
+					return nil, false, nil
+				}
+				return tt.pages[page], page == tt.lastAt, nil
+			}
+			got, complete, err := CollectPages(ctx, fetch, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got == nil || fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+			if fmt.Sprint(fetched) != fmt.Sprint(tt.wantFetched) {
+				t.Errorf("fetched pages = %v, want %v", fetched, tt.wantFetched)
+			}
+			if complete != tt.wantComplete {
+				t.Errorf("complete = %v, want %v", complete, tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestPaginateErrors(t *testing.T) {
+	boom := errors.New("boom")
+
+	t.Run("fetch error", func(t *testing.T) {
+		fetch := func(ctx context.Context, page int) ([]int, bool, error) {
+			if page == 1 {
+				return nil, false, boom
+			}
+			return []int{page}, false, nil
+		}
+		_, _, err := CollectPages(context.Background(), fetch, PaginateOptions{})
+		if !errors.Is(err, boom) {
+			t.Errorf("expected fetch error, got %v", err)
+		}
+	})
+
+	t.Run("yield error stops the walk", func(t *testing.T) {
+		calls := 0
+		fetch := func(ctx context.Context, page int) ([]int, bool, error) {
+			calls++
+			return []int{page}, false, nil
+		}
+		_, err := Paginate(context.Background(), fetch, PaginateOptions{}, func([]int) error { return boom })
+		if !errors.Is(err, boom) {
+			t.Errorf("expected yield error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 fetch, got %d", calls)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fetch := func(ctx context.Context, page int) ([]int, bool, error) {
+			t.Error("fetch should not be called")
+			return nil, false, nil
+		}
+		_, err := Paginate(ctx, fetch, PaginateOptions{}, func([]int) error { return nil })
+		var clientErr *ClientError
+		if !errors.As(err, &clientErr) || clientErr.Code != "CANCELLED" {
+			t.Errorf("expected CANCELLED, got %v", err)
+		}
+	})
+}
+
+func TestListTasksPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include_closed") != "true" {
+			t.Errorf("filters not preserved: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t1"},{"id":"t2"}],"last_page":false}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"tasks":[{"id":"t3"}],"last_page":true}`))
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL, Token: "test", HTTPClient: srv.Client()}
+
+	opts := &ListTasksOptions{IncludeClosed: true, Page: 7}
+	tasks, complete, err := CollectPages(context.Background(), ListTasksPages(c, "list1", opts), PaginateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 3 || tasks[2].ID != "t3" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+	if !complete {
+		t.Error("expected complete walk")
+	}
+	if opts.Page != 7 {
+		t.Errorf("caller options mutated: page = %d", opts.Page)
+	}
+}
+
+func TestTaskTemplatesPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "0":
+			_, _ = w.Write([]byte(`{"templates":[{"id":"a","name":"A"}]}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"templates":[{"id":"b","name":"B"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"templates":[]}`))
+		}
+	}))
+	defer srv.Close()
+	c := &Client{BaseURL: srv.URL, Token: "test", HTTPClient: srv.Client()}
+
+	templates, complete, err := CollectPages(context.Background(), TaskTemplatesPages(c, "team1"), PaginateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 2 || !complete {
+		t.Errorf("templates = %+v, complete = %v", templates, complete)
+	}
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that throttles requests made with one API
+// token. It learns the bucket from ClickUp's rate-limit headers: the size
+// from X-RateLimit-Limit (requests per minute), the current level from
+// X-RateLimit-Remaining, and X-RateLimit-Reset (Unix seconds) or
+// Retry-After for when an exhausted bucket refills. Until a response
+// reports a limit, requests are not throttled.
+type RateLimiter struct {
+	mu           sync.Mutex
+	limit        float64 // bucket size; 0 while unknown
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter returns a limiter that has not seen any rate-limit headers.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{now: time.Now}
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[[sha256.Size]byte]*RateLimiter{}
+)
+
+// SharedRateLimiter returns the limiter for token, shared by every client
+// in the process that uses the same token.
+func SharedRateLimiter(token string) *RateLimiter {
+	key := sha256.Sum256([]byte(token))
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[key]
+	if !ok {
+		l = NewRateLimiter()
+		limiters[key] = l
+	}
+	return l
+}
+
+// Wait blocks until the bucket has a token for one request, then takes it.
+// It returns a CANCELLED ClientError if ctx is done first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &ClientError{Code: "CANCELLED", Message: "request cancelled while waiting for the rate limit"}
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait before
+// trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.limit <= 0 {
+		return 0
+	}
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	perToken := time.Duration(float64(time.Minute) / l.limit)
+	return time.Duration((1 - l.tokens) * float64(perToken))
+}
+
+// refill adds the tokens earned since the last update at limit per minute.
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Minutes() * l.limit
+		if l.tokens > l.limit {
+			l.tokens = l.limit
+		}
+	}
+	l.last = now
+}
+
+// Update adjusts the bucket from a response's rate-limit headers. A
+// response with no remaining requests, or a 429, blocks the bucket until
+// the reported reset.
+func (l *RateLimiter) Update(statusCode int, h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	known := l.limit > 0
+	if limit, err := strconv.ParseFloat(h.Get("X-RateLimit-Limit"), 64); err == nil && limit > 0 {
+		l.limit = limit
+	}
+	if remaining, err := strconv.ParseFloat(h.Get("X-RateLimit-Remaining"), 64); err == nil && remaining >= 0 {
+		if l.limit <= 0 {
+			// No limit header: assume the bucket was full at remaining+1.
+			l.limit = remaining + 1
+		}
+		if known {
+			// remaining is the server's count when it answered, before
+			// requests still in flight, or may come from a late response:
+			// it can only lower the local count, never refill it.
+			l.refill(now)
+			l.tokens = math.Min(l.tokens, remaining)
+		} else {
+			l.tokens = remaining
+			l.last = now
+		}
+		if remaining < 1 {
+			if reset := resetTime(h); reset.After(l.blockedUntil) {
+				l.blockedUntil = reset
+			}
+		}
+	}
+	if statusCode == http.StatusTooManyRequests {
+		if wait := RetryAfter(h, now); wait > 0 && now.Add(wait).After(l.blockedUntil) {
+			l.blockedUntil = now.Add(wait)
+		}
+		l.tokens = 0
+		l.last = now
+	}
+}
+
+// RetryAfter returns how long a rate-limited response asks the client to
+// wait: Retry-After in seconds or as an HTTP date, else the time until
+// X-RateLimit-Reset. It returns 0 if neither header is usable.
+func RetryAfter(h http.Header, now time.Time) time.Duration {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return positive(t.Sub(now))
+		}
+	}
+	if reset := resetTime(h); !reset.IsZero() {
+		return positive(reset.Sub(now))
+	}
+	return 0
+}
+
+// resetTime parses X-RateLimit-Reset as Unix seconds, or returns the zero
+// time.
+func resetTime(h http.Header) time.Time {
+	secs, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a limiter whose clock only moves when advance is called.
+func fakeClock() (*RateLimiter, func(time.Duration)) {
+	now := time.Unix(1700000000, 0)
+	l := NewRateLimiter()
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func rateHeaders(limit, remaining int, reset time.Time) http.Header {
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return h
+}
+
+func TestRateLimiterUnknownLimit(t *testing.T) {
+	l, _ := fakeClock()
+	for i := 0; i < 1000; i++ {
+		if wait := l.reserve(); wait != 0 {
+			t.Fatalf("request %d throttled by %v before any headers were seen", i, wait)
+		}
+	}
+}
+
+func TestRateLimiterThrottles(t *testing.T) {
+	l, advance := fakeClock()
+	l.Update(200, rateHeaders(60, 2, l.now().Add(time.Minute)))
+
+	if wait := l.reserve(); wait != 0 {
+		t.Fatalf("first request waited %v", wait)
+	}
+	if wait := l.reserve(); wait != 0 {
+		t.Fatalf("second request waited %v", wait)
+	}
+	// 60 per minute refills one token per second.
+	if wait := l.reserve(); wait != time.Second {
+		t.Fatalf("expected 1s wait with an empty bucket, got %v", wait)
+	}
+	advance(time.Second)
+	if wait := l.reserve(); wait != 0 {
+		t.Fatalf("expected a refilled token, got wait %v", wait)
+	}
+}
+
+func TestRateLimiterBlocksUntilReset(t *testing.T) {
+	l, advance := fakeClock()
+	reset := l.now().Add(42 * time.Second)
+	l.Update(200, rateHeaders(100, 0, reset))
+
+	if wait := l.reserve(); wait != 42*time.Second {
+		t.Fatalf("expected to wait until reset (42s), got %v", wait)
+	}
+	advance(42 * time.Second)
+	if wait := l.reserve(); wait > time.Second {
+		t.Fatalf("still blocked after reset: %v", wait)
+	}
+}
+
+func TestRateLimiterInFlight(t *testing.T) {
+	l, _ := fakeClock()
+	reset := l.now().Add(time.Minute)
+	l.Update(200, rateHeaders(60, 8, reset))
+
+	// Eight requests take the bucket's tokens at once; their responses then
+	// arrive out of order, each reporting the count before the others.
+	var reserved, answered sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		reserved.Add(1)
+		answered.Add(1)
+		go func(i int) {
+			defer answered.Done()
+			wait := l.reserve()
+			reserved.Done()
+			if wait != 0 {
+				t.Errorf("request %d waited %v", i, wait)
+			}
+			reserved.Wait()
+			l.Update(200, rateHeaders(60, 7-i%4, reset))
+		}(i)
+	}
+	answered.Wait()
+	if wait := l.reserve(); wait != time.Second {
+		t.Errorf("expected late responses not to refill the bucket, got wait %v", wait)
+	}
+
+	// A lower count than the local one is taken.
+	l, _ = fakeClock()
+	l.Update(200, rateHeaders(60, 10, reset))
+	l.Update(200, rateHeaders(60, 1, reset))
+	if wait := l.reserve(); wait != 0 {
+		t.Fatalf("expected one token, got wait %v", wait)
+	}
+	if wait := l.reserve(); wait == 0 {
+		t.Error("expected the server's lower count to be used")
+	}
+}
+
+func TestRateLimiter429(t *testing.T) {
+	l, _ := fakeClock()
+	h := http.Header{}
+	h.Set("Retry-After", "7")
+	l.Update(http.StatusTooManyRequests, h)
+	if wait := l.reserve(); wait != 7*time.Second {
+		t.Fatalf("expected 7s wait after 429, got %v", wait)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := NewRateLimiter()
+	l.Update(200, rateHeaders(100, 0, time.Now().Add(time.Hour)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := l.Wait(ctx)
+	if ce, ok := err.(*ClientError); !ok || ce.Code != "CANCELLED" {
+		t.Fatalf("expected CANCELLED, got %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   time.Duration
+	}{
+		{"seconds", map[string]string{"Retry-After": "30"}, 30 * time.Second},
+		{"http date", map[string]string{"Retry-After": now.Add(90 * time.Second).UTC().Format(http.TimeFormat)}, 90 * time.Second},
+		{"reset header", map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Unix()+12, 10)}, 12 * time.Second},
+		{"reset in the past", map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Unix()-5, 10)}, 0},
+		{"retry-after wins", map[string]string{"Retry-After": "3", "X-RateLimit-Reset": strconv.FormatInt(now.Unix()+60, 10)}, 3 * time.Second},
+		{"none", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.header {
+				h.Set(k, v)
+			}
+			if got := RetryAfter(h, now); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	if SharedRateLimiter("pk_a") != SharedRateLimiter("pk_a") {
+		t.Error("expected one limiter per token")
+	}
+	if SharedRateLimiter("pk_a") == SharedRateLimiter("pk_b") {
+		t.Error("expected separate limiters for different tokens")
+	}
+}
+
+func TestClientDo_RetryAfterReset(t *testing.T) {
+	var calls int32
+	var first time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"err":"Rate limit reached"}`))
+			return
+		}
+		if elapsed := time.Since(first); elapsed < 900*time.Millisecond {
+			t.Errorf("retried after %v, before Retry-After elapsed", elapsed)
+		}
+		_, _ = w.Write([]byte(`{"user":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pk_test")
+	client.BaseURL = server.URL
+	client.RateLimiter = NewRateLimiter()
+	// Exponential backoff alone would wait far longer than the test timeout.
+	client.RetryBaseWait = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.GetUser(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
@@ -20,13 +20,13 @@ type CustomField struct {
 }
 
 type Checklist struct {
-	ID         string      `json:"id"`
-	TaskID     string      `json:"task_id"`
-	Name       string      `json:"name"`
-	OrderIndex int         `json:"orderindex"`
-	Resolved   int         `json:"resolved"`
-	Unresolved int         `json:"unresolved"`
-	Items      interface{} `json:"items,omitempty"`
+	ID         string          `json:"id"`
+	TaskID     string          `json:"task_id"`
+	Name       string          `json:"name"`
+	OrderIndex int             `json:"orderindex"`
+	Resolved   int             `json:"resolved"`
+	Unresolved int             `json:"unresolved"`
+	Items      []ChecklistItem `json:"items,omitempty"`
 }
 
 type LinkedTask struct {
@@ -59,13 +59,13 @@ type Task struct {
 	Assignees           []User        `json:"assignees"`
 	Watchers            []User        `json:"watchers,omitempty"`
 	Tags                []TaskTag     `json:"tags"`
-	Parent              interface{}   `json:"parent"`
+	Parent              *string       `json:"parent"`
 	Priority            *TaskPriority `json:"priority"`
 	DueDate             string        `json:"due_date,omitempty"`
 	StartDate           string        `json:"start_date,omitempty"`
-	Points              interface{}   `json:"points"`
-	TimeEstimate        interface{}   `json:"time_estimate"`
-	TimeSpent           interface{}   `json:"time_spent,omitempty"`
+	Points              *FlexFloat    `json:"points"`
+	TimeEstimate        *FlexInt      `json:"time_estimate"`
+	TimeSpent           *FlexInt      `json:"time_spent,omitempty"`
 	CustomFields        []CustomField `json:"custom_fields,omitempty"`
 	Checklists          []Checklist   `json:"checklists,omitempty"`
 	LinkedTasks         []LinkedTask  `json:"linked_tasks,omitempty"`
@@ -105,7 +105,8 @@ type TaskPriority struct {
 }
 
 type TasksResponse struct {
-	Tasks []Task `json:"tasks"`
+	Tasks    []Task `json:"tasks"`
+	LastPage bool   `json:"last_page"`
 }
 
 type ListTasksOptions struct {
@@ -254,8 +255,9 @@ func (c *Client) GetTask(ctx context.Context, taskID string, opts ...GetTaskOpti
 }
 
 type CustomFieldValue struct {
-	ID    string      `json:"id"`
-	Value interface{} `json:"value"`
+	ID           string                   `json:"id"`
+	Value        interface{}              `json:"value"`
+	ValueOptions *CustomFieldValueOptions `json:"value_options,omitempty"`
 }
 
 type CreateTaskRequest struct {
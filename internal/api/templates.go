@@ -12,6 +12,8 @@ type TaskTemplate struct {
 
 type TaskTemplatesResponse struct {
 	Templates []TaskTemplate `json:"templates"`
+	// LastPage is only set by a multi-page walk; the endpoint does not send it.
+	LastPage *bool `json:"last_page,omitempty"`
 }
 
 type CreateFromTemplateRequest struct {
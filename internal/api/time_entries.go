@@ -6,21 +6,29 @@ import (
 )
 
 type TimeEntry struct {
-	ID           string      `json:"id"`
-	Task         interface{} `json:"task,omitempty"`
-	Wid          string      `json:"wid,omitempty"`
-	User         interface{} `json:"user,omitempty"`
-	Billable     bool        `json:"billable"`
-	Start        string      `json:"start"`
-	End          string      `json:"end,omitempty"`
-	Duration     string      `json:"duration"`
-	Description  string      `json:"description"`
-	Tags         []Tag       `json:"tags,omitempty"`
-	Source       string      `json:"source,omitempty"`
-	At           string      `json:"at,omitempty"`
-	TaskLocation interface{} `json:"task_location,omitempty"`
-	TaskTags     interface{} `json:"task_tags,omitempty"`
-	TaskURL      string      `json:"task_url,omitempty"`
+	ID           string         `json:"id"`
+	Task         *TimeEntryTask `json:"task,omitempty"`
+	Wid          string         `json:"wid,omitempty"`
+	User         *User          `json:"user,omitempty"`
+	Billable     bool           `json:"billable"`
+	Start        string         `json:"start"`
+	End          string         `json:"end,omitempty"`
+	Duration     string         `json:"duration"`
+	Description  string         `json:"description"`
+	Tags         []Tag          `json:"tags,omitempty"`
+	Source       string         `json:"source,omitempty"`
+	At           string         `json:"at,omitempty"`
+	TaskLocation interface{}    `json:"task_location,omitempty"`
+	TaskTags     interface{}    `json:"task_tags,omitempty"`
+	TaskURL      string         `json:"task_url,omitempty"`
+}
+
+// TimeEntryTask is the task a time entry is tracked against.
+type TimeEntryTask struct {
+	ID       string      `json:"id"`
+	CustomID string      `json:"custom_id,omitempty"`
+	Name     string      `json:"name"`
+	Status   *TaskStatus `json:"status,omitempty"`
 }
 
 type TimeEntriesResponse struct {
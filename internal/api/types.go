@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// FlexInt is an integer, such as a duration in milliseconds, that ClickUp
+// sends as a JSON number in some responses and as a string in others. An
+// empty string reads as 0. It marshals as a number.
+type FlexInt int64
+
+func (n *FlexInt) UnmarshalJSON(data []byte) error {
+	s, err := flexNumber(data)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return fmt.Errorf("invalid integer %s", data)
+		}
+		v = int64(f)
+	}
+	*n = FlexInt(v)
+	return nil
+}
+
+// String formats n in base 10, or returns "" for nil.
+func (n *FlexInt) String() string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(*n), 10)
+}
+
+// FlexFloat is a number, such as sprint points, that ClickUp sends as a JSON
+// number in some responses and as a string in others. An empty string reads
+// as 0. It marshals as a number.
+type FlexFloat float64
+
+func (f *FlexFloat) UnmarshalJSON(data []byte) error {
+	s, err := flexNumber(data)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*f = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	*f = FlexFloat(v)
+	return nil
+}
+
+// String formats f without trailing zeros, or returns "" for nil.
+func (f *FlexFloat) String() string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(*f), 'f', -1, 64)
+}
+
+// flexNumber returns the text of a JSON number, a string, or null ("").
+func flexNumber(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", fmt.Errorf("expected a number or string, got %s", data)
+	}
+	return n.String(), nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexNumbers(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantInt int64
+		wantErr bool
+	}{
+		{in: `7200000`, wantInt: 7200000},
+		{in: `"7200000"`, wantInt: 7200000},
+		{in: `""`, wantInt: 0},
+		{in: `null`, wantInt: 0},
+		{in: `3.0`, wantInt: 3},
+		{in: `"soon"`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var n FlexInt
+		err := json.Unmarshal([]byte(tt.in), &n)
+		if (err != nil) != tt.wantErr || int64(n) != tt.wantInt {
+			t.Errorf("FlexInt %s: got %d, %v", tt.in, n, err)
+		}
+	}
+
+	var f FlexFloat
+	if err := json.Unmarshal([]byte(`"2.5"`), &f); err != nil || f != 2.5 {
+		t.Errorf("FlexFloat: got %v, %v", f, err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), &f); err == nil {
+		t.Error("expected an object to fail")
+	}
+	var nilInt *FlexInt
+	var nilFloat *FlexFloat
+	if nilInt.String() != "" || nilFloat.String() != "" || f.String() != "2.5" {
+		t.Errorf("unexpected String results")
+	}
+}
+
+func TestTypedModels(t *testing.T) {
+	var task Task
+	data := `{"id":"t1","parent":"p1","points":"3","time_estimate":"7200000","time_spent":1500,
+		"checklists":[{"id":"c1","items":[{"id":"i1","name":"Step","resolved":true,"orderindex":"2","assignee":{"id":42,"username":"alice"},"parent":null,
+			"children":[{"id":"i2","name":"Substep","orderindex":0,"assignee":null,"parent":"i1"}]}]}]}`
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatal(err)
+	}
+	if *task.Parent != "p1" || *task.Points != 3 || *task.TimeEstimate != 7200000 || *task.TimeSpent != 1500 {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if items := task.Checklists[0].Items; len(items) != 1 || items[0].Name != "Step" || !items[0].Resolved {
+		t.Errorf("unexpected checklist items: %+v", items)
+	}
+	item := task.Checklists[0].Items[0]
+	if item.OrderIndex != 2 || item.Assignee.ID != 42 || item.Parent != nil || len(item.Children) != 1 || *item.Children[0].Parent != "i1" || item.Children[0].Assignee != nil {
+		t.Errorf("unexpected checklist item: %+v", item)
+	}
+	out, _ := json.Marshal(Task{ID: "t2"})
+	var m map[string]interface{}
+	_ = json.Unmarshal(out, &m)
+	if _, ok := m["parent"]; !ok || m["parent"] != nil || m["points"] != nil || m["time_estimate"] != nil {
+		t.Errorf("expected unset fields to stay null, got %s", out)
+	}
+
+	var entry TimeEntry
+	data = `{"id":"e1","task":{"id":"t1","name":"Fix bug","status":{"status":"open"}},"user":{"id":42,"username":"alice"}}`
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Task.Name != "Fix bug" || entry.Task.Status.Status != "open" || entry.User.ID != 42 {
+		t.Errorf("unexpected time entry: %+v", entry)
+	}
+
+	var view View
+	data = `{"id":"v1","grouping":{"field":"status","dir":1,"collapsed":[],"ignore":false},
+		"sorting":{"fields":[{"field":"dateCreated","dir":-1}]},
+		"filters":{"op":"AND","fields":[{"field":"assignee","op":"ANY","values":[183]}],"search":"","show_closed":false}}`
+	if err := json.Unmarshal([]byte(data), &view); err != nil {
+		t.Fatal(err)
+	}
+	if view.Grouping.Field != "status" || view.Sorting.Fields[0].Dir != -1 || view.Filters.Op != "AND" || string(view.Filters.Fields[0].Values) != "[183]" {
+		t.Errorf("unexpected view: %+v", view)
+	}
+
+	var wh Webhook
+	if err := json.Unmarshal([]byte(`{"id":"w1","events":["*"],"health":{"status":"failing","fail_count":3}}`), &wh); err != nil {
+		t.Fatal(err)
+	}
+	if wh.Health.Status != "failing" || wh.Health.FailCount != 3 || wh.Events[0] != "*" {
+		t.Errorf("unexpected webhook: %+v", wh)
+	}
+
+	var doc Doc
+	if err := json.Unmarshal([]byte(`{"id":"d1","creator":183}`), &doc); err != nil || doc.Creator != "183" {
+		t.Errorf("unexpected doc: %+v, %v", doc, err)
+	}
+}
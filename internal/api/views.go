@@ -2,20 +2,58 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
 type View struct {
-	ID       string      `json:"id"`
-	Name     string      `json:"name"`
-	Type     string      `json:"type"`
-	Parent   interface{} `json:"parent,omitempty"`
-	Grouping interface{} `json:"grouping,omitempty"`
-	Divide   interface{} `json:"divide,omitempty"`
-	Sorting  interface{} `json:"sorting,omitempty"`
-	Filters  interface{} `json:"filters,omitempty"`
-	Columns  interface{} `json:"columns,omitempty"`
-	Settings interface{} `json:"settings,omitempty"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"`
+	Parent   interface{}   `json:"parent,omitempty"`
+	Grouping *ViewGrouping `json:"grouping,omitempty"`
+	Divide   interface{}   `json:"divide,omitempty"`
+	Sorting  *ViewSorting  `json:"sorting,omitempty"`
+	Filters  *ViewFilters  `json:"filters,omitempty"`
+	Columns  interface{}   `json:"columns,omitempty"`
+	Settings interface{}   `json:"settings,omitempty"`
+}
+
+// ViewGrouping is the field a view groups tasks by. Dir is 1 for
+// ascending and -1 for descending.
+type ViewGrouping struct {
+	Field     string   `json:"field"`
+	Dir       int      `json:"dir"`
+	Collapsed []string `json:"collapsed,omitempty"`
+	Ignore    bool     `json:"ignore,omitempty"`
+}
+
+// ViewSorting lists the fields a view sorts tasks by, in order.
+type ViewSorting struct {
+	Fields []ViewSortField `json:"fields"`
+}
+
+type ViewSortField struct {
+	Field string `json:"field"`
+	Dir   int    `json:"dir"`
+	Idx   int    `json:"idx,omitempty"`
+}
+
+// ViewFilters are the conditions a view's tasks match, combined with Op
+// (AND or OR).
+type ViewFilters struct {
+	Op         string            `json:"op"`
+	Fields     []ViewFilterField `json:"fields"`
+	Search     string            `json:"search,omitempty"`
+	ShowClosed bool              `json:"show_closed"`
+}
+
+// ViewFilterField is one filter condition. Values depend on Field: status
+// names, user IDs, dates and so on.
+type ViewFilterField struct {
+	Field  string          `json:"field"`
+	Op     string          `json:"op"`
+	Values json.RawMessage `json:"values,omitempty"`
 }
 
 type ViewsResponse struct {
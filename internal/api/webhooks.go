@@ -6,18 +6,26 @@ import (
 )
 
 type Webhook struct {
-	ID       string      `json:"id"`
-	UserID   int         `json:"userid"`
-	TeamID   int         `json:"team_id"`
-	Endpoint string      `json:"endpoint"`
-	ClientID string      `json:"client_id"`
-	Events   interface{} `json:"events"`
-	TaskID   interface{} `json:"task_id"`
-	ListID   interface{} `json:"list_id"`
-	FolderID interface{} `json:"folder_id"`
-	SpaceID  interface{} `json:"space_id"`
-	Health   interface{} `json:"health"`
-	Secret   string      `json:"secret"`
+	ID       string         `json:"id"`
+	UserID   int            `json:"userid"`
+	TeamID   int            `json:"team_id"`
+	Endpoint string         `json:"endpoint"`
+	ClientID string         `json:"client_id"`
+	Events   []string       `json:"events"`
+	TaskID   interface{}    `json:"task_id"`
+	ListID   interface{}    `json:"list_id"`
+	FolderID interface{}    `json:"folder_id"`
+	SpaceID  interface{}    `json:"space_id"`
+	Health   *WebhookHealth `json:"health"`
+	Secret   string         `json:"secret"`
+}
+
+// WebhookHealth reports whether ClickUp still delivers to a webhook: Status
+// is active, failing or suspended, and FailCount counts failed deliveries in
+// a row.
+type WebhookHealth struct {
+	Status    string `json:"status"`
+	FailCount int    `json:"fail_count"`
 }
 
 type WebhooksResponse struct {
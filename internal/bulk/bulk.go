@@ -0,0 +1,112 @@
+// Package bulk runs one operation over many items with bounded concurrency
+// and collects a per-item outcome.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// DefaultConcurrency is how many items Run processes at once by default.
+const DefaultConcurrency = 4
+
+// Result is the outcome of one item, in input order.
+type Result struct {
+	Index int         `json:"index"`
+	ID    string      `json:"id,omitempty"`
+	OK    bool        `json:"ok"`
+	Error *ItemError  `json:"error,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+// ItemError is why an item failed, with the same codes as CLI errors.
+type ItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Summary reports every item of a run.
+type Summary struct {
+	Total     int      `json:"total"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Results   []Result `json:"results"`
+}
+
+// Func processes item i and returns the ID of the object it touched, data
+// to report with it (such as a created task), and any error. id is reported
+// even when err is not nil.
+type Func func(ctx context.Context, i int) (id string, data interface{}, err error)
+
+// Run calls fn for items 0 to n-1, at most concurrency at a time. A failed
+// item does not stop the others; cancelling ctx skips the items not yet
+// started, which are reported as CANCELLED. An item stopped by a dry-run
+// client succeeds, with the request it would have sent as its data.
+func Run(ctx context.Context, n, concurrency int, fn Func) *Summary {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	results := make([]Result, n)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		results[i].Index = i
+		if !acquire(ctx, sem) {
+			results[i].Error = itemError(&api.ClientError{Code: "CANCELLED", Message: ctx.Err().Error()})
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			id, data, err := fn(ctx, i)
+			results[i].ID = id
+			var dryRun *api.DryRunError
+			if errors.As(err, &dryRun) {
+				// In a dry run the item's first change is its result.
+				err, data = nil, dryRun.Request
+			}
+			if err != nil {
+				results[i].Error = itemError(err)
+				return
+			}
+			results[i].OK = true
+			results[i].Data = data
+		}(i)
+	}
+	wg.Wait()
+
+	s := &Summary{Total: n, Results: results}
+	for _, r := range results {
+		if r.OK {
+			s.Succeeded++
+		} else {
+			s.Failed++
+		}
+	}
+	return s
+}
+
+// acquire takes a slot in sem, or reports false once ctx is done.
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func itemError(err error) *ItemError {
+	var ce *api.ClientError
+	if errors.As(err, &ce) {
+		return &ItemError{Code: ce.Code, Message: ce.Message}
+	}
+	return &ItemError{Code: "ERROR", Message: err.Error()}
+}
@@ -0,0 +1,90 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+func TestRun(t *testing.T) {
+	s := Run(context.Background(), 4, 2, func(_ context.Context, i int) (string, interface{}, error) {
+		id := strconv.Itoa(i)
+		switch i {
+		case 1:
+			return id, nil, &api.ClientError{Code: "NOT_FOUND", Message: "Task not found"}
+		case 2:
+			return id, nil, errors.New("boom")
+		}
+		return id, i * 10, nil
+	})
+	if s.Total != 4 || s.Succeeded != 2 || s.Failed != 2 {
+		t.Fatalf("got %d total, %d succeeded, %d failed", s.Total, s.Succeeded, s.Failed)
+	}
+	for i, r := range s.Results {
+		if r.Index != i || r.ID != strconv.Itoa(i) {
+			t.Errorf("result %d out of order: %+v", i, r)
+		}
+	}
+	if e := s.Results[1].Error; e == nil || e.Code != "NOT_FOUND" || e.Message != "Task not found" {
+		t.Errorf("expected the API error code, got %+v", e)
+	}
+	if e := s.Results[2].Error; e == nil || e.Code != "ERROR" || e.Message != "boom" {
+		t.Errorf("expected a generic error, got %+v", e)
+	}
+	if !s.Results[3].OK || s.Results[3].Data != 30 {
+		t.Errorf("expected data with a success, got %+v", s.Results[3])
+	}
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	var running, peak int32
+	Run(context.Background(), 20, 3, func(context.Context, int) (string, interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return "", nil, nil
+	})
+	if peak > 3 {
+		t.Errorf("expected at most 3 items at once, got %d", peak)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := Run(ctx, 3, 1, func(context.Context, int) (string, interface{}, error) {
+		return "", nil, nil
+	})
+	if s.Failed != 3 {
+		t.Fatalf("expected every item to be skipped, got %d failed", s.Failed)
+	}
+	for _, r := range s.Results {
+		if r.Error == nil || r.Error.Code != "CANCELLED" {
+			t.Errorf("expected CANCELLED, got %+v", r.Error)
+		}
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	req := api.DryRunRequest{Method: "PUT", Path: "/v2/task/0"}
+	s := Run(context.Background(), 1, 1, func(context.Context, int) (string, interface{}, error) {
+		return "0", nil, &api.DryRunError{Request: req}
+	})
+	if s.Succeeded != 1 {
+		t.Fatalf("expected a dry-run item to succeed, got %+v", s.Results[0])
+	}
+	if got, ok := s.Results[0].Data.(api.DryRunRequest); !ok || got.Method != "PUT" || got.Path != req.Path {
+		t.Errorf("expected the request as data, got %+v", s.Results[0].Data)
+	}
+}
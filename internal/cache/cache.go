@@ -0,0 +1,230 @@
+// Package cache keeps workspace hierarchy and member listings on disk, so
+// name lookups and browsing do not refetch them on every invocation.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dirEnv overrides the cache directory.
+const dirEnv = "CLICKUP_CACHE_DIR"
+
+// version is bumped when the file layout changes; files with another
+// version are ignored.
+const version = 1
+
+// Dir returns the directory holding cache files: $CLICKUP_CACHE_DIR, or
+// clickup-cli under the user cache directory (~/.cache on Linux).
+func Dir() (string, error) {
+	if dir := os.Getenv(dirEnv); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "clickup-cli"), nil
+}
+
+// entry is a cached API response and when it was fetched.
+type entry struct {
+	Fetched time.Time       `json:"fetched"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type file struct {
+	Version int              `json:"version"`
+	Entries map[string]entry `json:"entries"`
+}
+
+// Store is the cache file of one workspace. It is safe for concurrent use.
+type Store struct {
+	Path string
+
+	mu      sync.Mutex
+	entries map[string]entry
+	now     func() time.Time
+}
+
+var (
+	storesMu sync.Mutex
+	stores   = map[string]*Store{}
+)
+
+// Open returns the store named key in dir, such as a workspace ID. Stores
+// are shared within the process, so every client using a key sees the same
+// entries.
+func Open(dir, key string) *Store {
+	path := filepath.Join(dir, key+".json")
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	if s, ok := stores[path]; ok {
+		return s
+	}
+	s := &Store{Path: path, now: time.Now}
+	stores[path] = s
+	return s
+}
+
+// load reads the file on first use. A missing or unreadable file is an
+// empty cache.
+func (s *Store) load() {
+	if s.entries != nil {
+		return
+	}
+	s.entries = map[string]entry{}
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return
+	}
+	var f file
+	if json.Unmarshal(data, &f) != nil || f.Version != version {
+		return
+	}
+	for k, e := range f.Entries {
+		s.entries[k] = e
+	}
+}
+
+// Get decodes the entry for key into v and reports whether it was found
+// and fetched less than ttl ago.
+func (s *Store) Get(key string, ttl time.Duration, v interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	e, ok := s.entries[key]
+	if !ok || s.now().Sub(e.Fetched) >= ttl {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Put stores v under key and writes the file.
+func (s *Store) Put(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	s.entries[key] = entry{Fetched: s.now(), Data: data}
+	return s.save()
+}
+
+// Invalidate removes the entries whose keys start with any of prefixes and
+// writes the file.
+func (s *Store) Invalidate(prefixes ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	removed := false
+	for k := range s.entries {
+		for _, p := range prefixes {
+			if strings.HasPrefix(k, p) {
+				delete(s.entries, k)
+				removed = true
+				break
+			}
+		}
+	}
+	if !removed {
+		return nil
+	}
+	return s.save()
+}
+
+// Clear removes every entry and the file.
+func (s *Store) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = map[string]entry{}
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// save writes the entries to a temporary file and renames it over the
+// cache file, so a concurrent reader never sees a partial file.
+func (s *Store) save() error {
+	data, err := json.Marshal(file{Version: version, Entries: s.entries})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".cache-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Key names the cache of workspaceID for one API endpoint and account: the
+// workspace ID followed by a hash of parts, such as the base URL and token,
+// so that a mock server or another account never shares entries with the
+// real workspace.
+func Key(workspaceID string, parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return workspaceID + "-" + hex.EncodeToString(h.Sum(nil)[:6])
+}
+
+// ClearWorkspace removes the cache files of workspaceID in dir, whatever
+// parts their Key was made with.
+func ClearWorkspace(dir, workspaceID string) error {
+	return clearFiles(dir, workspaceID+"-*.json")
+}
+
+// ClearAll removes the cache files of every workspace in dir.
+func ClearAll(dir string) error {
+	return clearFiles(dir, "*.json")
+}
+
+// clearFiles removes the files in dir matching pattern and empties their
+// open stores. Only cache files are matched, in case dir is shared with
+// other files.
+func clearFiles(dir, pattern string) error {
+	storesMu.Lock()
+	for _, s := range stores {
+		if ok, _ := filepath.Match(pattern, filepath.Base(s.Path)); ok && filepath.Dir(s.Path) == dir {
+			s.mu.Lock()
+			s.entries = nil
+			s.mu.Unlock()
+		}
+	}
+	storesMu.Unlock()
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
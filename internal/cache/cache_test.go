@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/testutil"
+)
+
+// newStore returns a store in a temporary directory whose clock only moves
+// when advance is called.
+func newStore(t *testing.T) (*Store, func(time.Duration)) {
+	t.Helper()
+	now := time.Unix(1700000000, 0)
+	s := &Store{Path: filepath.Join(t.TempDir(), "9.json"), now: func() time.Time { return now }}
+	return s, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestStoreTTL(t *testing.T) {
+	s, advance := newStore(t)
+	if err := s.Put("spaces/9", []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	if !s.Get("spaces/9", time.Minute, &got) || len(got) != 1 {
+		t.Fatalf("expected a fresh entry, got %v", got)
+	}
+	advance(time.Minute)
+	if s.Get("spaces/9", time.Minute, &got) {
+		t.Error("expected the entry to expire after its TTL")
+	}
+}
+
+func TestStorePersists(t *testing.T) {
+	s, _ := newStore(t)
+	if err := s.Put("folders/1", map[string]string{"id": "10"}); err != nil {
+		t.Fatal(err)
+	}
+	reopened := &Store{Path: s.Path, now: s.now}
+	var got map[string]string
+	if !reopened.Get("folders/1", time.Hour, &got) || got["id"] != "10" {
+		t.Errorf("expected the entry to be read back from disk, got %v", got)
+	}
+}
+
+func TestStoreIgnoresCorruptFile(t *testing.T) {
+	s, _ := newStore(t)
+	if err := os.WriteFile(s.Path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	if s.Get("spaces/9", time.Hour, &got) {
+		t.Error("expected a miss from a corrupt file")
+	}
+	if err := s.Put("spaces/9", []string{"a"}); err != nil {
+		t.Fatalf("expected the file to be rewritten, got %v", err)
+	}
+}
+
+func TestStoreInvalidateAndClear(t *testing.T) {
+	s, _ := newStore(t)
+	for _, key := range []string{"spaces/9", "folders/1", "members/100"} {
+		if err := s.Put(key, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Invalidate(hierarchyKeys...); err != nil {
+		t.Fatal(err)
+	}
+	var v int
+	if s.Get("spaces/9", time.Hour, &v) || s.Get("folders/1", time.Hour, &v) {
+		t.Error("expected hierarchy entries to be invalidated")
+	}
+	if !s.Get("members/100", time.Hour, &v) {
+		t.Error("expected members to survive a hierarchy invalidation")
+	}
+	if err := s.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(s.Path); !os.IsNotExist(err) {
+		t.Errorf("expected the file to be removed, got %v", err)
+	}
+}
+
+func TestClearAll(t *testing.T) {
+	dir := t.TempDir()
+	other := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(other, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s := Open(dir, "9")
+	if err := s.Put("spaces/9", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ClearAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	var v int
+	if s.Get("spaces/9", time.Hour, &v) {
+		t.Error("expected open stores to be emptied")
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("expected other files to be kept: %v", err)
+	}
+}
+
+func TestClearWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	s := Open(dir, Key("9", "https://api.clickup.com/api", "pk_a"))
+	other := Open(dir, Key("90", "https://api.clickup.com/api", "pk_a"))
+	if s.Path == Open(dir, Key("9", "http://localhost:8080", "pk_a")).Path {
+		t.Error("expected different base URLs to get different keys")
+	}
+	for _, store := range []*Store{s, other} {
+		if err := store.Put("spaces/9", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ClearWorkspace(dir, "9"); err != nil {
+		t.Fatal(err)
+	}
+	var v int
+	if s.Get("spaces/9", time.Hour, &v) {
+		t.Error("expected the workspace's stores to be emptied")
+	}
+	if !other.Get("spaces/9", time.Hour, &v) {
+		t.Error("expected other workspaces to be kept")
+	}
+	if _, err := os.Stat(other.Path); err != nil {
+		t.Errorf("expected other workspaces' files to be kept: %v", err)
+	}
+}
+
+func TestClient(t *testing.T) {
+	calls := map[string]int{}
+	mock := &testutil.MockClient{
+		ListSpacesFn: func(context.Context, string) (*api.SpacesResponse, error) {
+			calls["spaces"]++
+			return &api.SpacesResponse{Spaces: []api.Space{{ID: "1", Name: "Engineering"}}}, nil
+		},
+		GetListMembersFn: func(context.Context, string) (*api.MembersResponse, error) {
+			calls["members"]++
+			return &api.MembersResponse{Members: []api.Member{{ID: 7, Username: "alice"}}}, nil
+		},
+		CreateListFn: func(context.Context, string, *api.CreateListRequest) (*api.List, error) {
+			return &api.List{ID: "100"}, nil
+		},
+	}
+	s, advance := newStore(t)
+	c := NewClient(mock, s)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.ListSpaces(ctx, "9")
+		if err != nil || len(resp.Spaces) != 1 || resp.Spaces[0].Name != "Engineering" {
+			t.Fatalf("ListSpaces = %+v, %v", resp, err)
+		}
+		if _, err := c.GetListMembers(ctx, "100"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls["spaces"] != 1 || calls["members"] != 1 {
+		t.Fatalf("expected one call each, got %v", calls)
+	}
+
+	if _, err := c.CreateList(ctx, "10", &api.CreateListRequest{Name: "Bugs"}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = c.ListSpaces(ctx, "9")
+	_, _ = c.GetListMembers(ctx, "100")
+	if calls["spaces"] != 2 || calls["members"] != 1 {
+		t.Errorf("expected creating a list to invalidate only the hierarchy, got %v", calls)
+	}
+
+	advance(DefaultTTL)
+	_, _ = c.ListSpaces(ctx, "9")
+	_, _ = c.GetListMembers(ctx, "100")
+	if calls["spaces"] != 3 || calls["members"] != 1 {
+		t.Errorf("expected spaces to expire before members, got %v", calls)
+	}
+
+	c.Refresh = true
+	_, _ = c.GetListMembers(ctx, "100")
+	c.Refresh = false
+	_, _ = c.GetListMembers(ctx, "100")
+	if calls["members"] != 2 {
+		t.Errorf("expected Refresh to refetch once and store the result, got %v", calls)
+	}
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// Default TTLs. Members change less often than the hierarchy.
+const (
+	DefaultTTL        = 15 * time.Minute
+	DefaultMembersTTL = time.Hour
+)
+
+// hierarchyKeys are the key prefixes of spaces, folders and lists, dropped
+// whenever one of them is created, changed or deleted.
+var hierarchyKeys = []string{"spaces/", "folders/", "lists/", "folderless/"}
+
+// Client wraps an API client, answering hierarchy and member listings from
+// a Store while they are fresh. Calls that create, update or delete spaces,
+// folders or lists invalidate the cached hierarchy. Every other call goes
+// straight to the wrapped client.
+type Client struct {
+	api.ClientInterface
+	Store *Store
+
+	// TTL applies to spaces, folders and lists, MembersTTL to list and
+	// workspace members.
+	TTL        time.Duration
+	MembersTTL time.Duration
+
+	// Refresh skips cached entries but still stores what is fetched.
+	Refresh bool
+}
+
+// NewClient returns a caching client over c with the default TTLs.
+func NewClient(c api.ClientInterface, store *Store) *Client {
+	return &Client{ClientInterface: c, Store: store, TTL: DefaultTTL, MembersTTL: DefaultMembersTTL}
+}
+
+// cached returns the entry for key if fresh, or calls fetch and stores its
+// result. A cache that cannot be written does not fail the call.
+func cached[T any](c *Client, key string, ttl time.Duration, fetch func() (*T, error)) (*T, error) {
+	var v T
+	if !c.Refresh && c.Store.Get(key, ttl, &v) {
+		return &v, nil
+	}
+	resp, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	_ = c.Store.Put(key, resp)
+	return resp, nil
+}
+
+// invalidate drops the cached hierarchy after a successful change.
+func (c *Client) invalidate(err error) {
+	if err == nil {
+		_ = c.Store.Invalidate(hierarchyKeys...)
+	}
+}
+
+func (c *Client) ListWorkspaces(ctx context.Context) (*api.WorkspacesResponse, error) {
+	return cached(c, "workspaces", c.MembersTTL, func() (*api.WorkspacesResponse, error) {
+		return c.ClientInterface.ListWorkspaces(ctx)
+	})
+}
+
+func (c *Client) GetListMembers(ctx context.Context, listID string) (*api.MembersResponse, error) {
+	return cached(c, "members/"+listID, c.MembersTTL, func() (*api.MembersResponse, error) {
+		return c.ClientInterface.GetListMembers(ctx, listID)
+	})
+}
+
+func (c *Client) ListSpaces(ctx context.Context, workspaceID string) (*api.SpacesResponse, error) {
+	return cached(c, "spaces/"+workspaceID, c.TTL, func() (*api.SpacesResponse, error) {
+		return c.ClientInterface.ListSpaces(ctx, workspaceID)
+	})
+}
+
+// ListFolders answers from the cache, unless archived folders are asked
+// for, which are not cached.
+func (c *Client) ListFolders(ctx context.Context, spaceID string, opts ...api.ListFoldersOptions) (*api.FoldersResponse, error) {
+	if len(opts) > 0 && opts[0].Archived {
+		return c.ClientInterface.ListFolders(ctx, spaceID, opts...)
+	}
+	return cached(c, "folders/"+spaceID, c.TTL, func() (*api.FoldersResponse, error) {
+		return c.ClientInterface.ListFolders(ctx, spaceID)
+	})
+}
+
+// ListLists answers from the cache, unless archived lists are asked for,
+// which are not cached.
+func (c *Client) ListLists(ctx context.Context, folderID string, opts ...api.ListListsOptions) (*api.ListsResponse, error) {
+	if len(opts) > 0 && opts[0].Archived {
+		return c.ClientInterface.ListLists(ctx, folderID, opts...)
+	}
+	return cached(c, "lists/"+folderID, c.TTL, func() (*api.ListsResponse, error) {
+		return c.ClientInterface.ListLists(ctx, folderID)
+	})
+}
+
+// ListFolderlessLists answers from the cache, unless archived lists are
+// asked for, which are not cached.
+func (c *Client) ListFolderlessLists(ctx context.Context, spaceID string, opts ...api.ListListsOptions) (*api.ListsResponse, error) {
+	if len(opts) > 0 && opts[0].Archived {
+		return c.ClientInterface.ListFolderlessLists(ctx, spaceID, opts...)
+	}
+	return cached(c, "folderless/"+spaceID, c.TTL, func() (*api.ListsResponse, error) {
+		return c.ClientInterface.ListFolderlessLists(ctx, spaceID)
+	})
+}
+
+func (c *Client) CreateSpace(ctx context.Context, workspaceID string, req *api.CreateSpaceRequest) (*api.Space, error) {
+	resp, err := c.ClientInterface.CreateSpace(ctx, workspaceID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) UpdateSpace(ctx context.Context, spaceID string, req *api.UpdateSpaceRequest) (*api.Space, error) {
+	resp, err := c.ClientInterface.UpdateSpace(ctx, spaceID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) DeleteSpace(ctx context.Context, spaceID string) error {
+	err := c.ClientInterface.DeleteSpace(ctx, spaceID)
+	c.invalidate(err)
+	return err
+}
+
+func (c *Client) CreateFolder(ctx context.Context, spaceID string, req *api.CreateFolderRequest) (*api.Folder, error) {
+	resp, err := c.ClientInterface.CreateFolder(ctx, spaceID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) UpdateFolder(ctx context.Context, folderID string, req *api.UpdateFolderRequest) (*api.Folder, error) {
+	resp, err := c.ClientInterface.UpdateFolder(ctx, folderID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) DeleteFolder(ctx context.Context, folderID string) error {
+	err := c.ClientInterface.DeleteFolder(ctx, folderID)
+	c.invalidate(err)
+	return err
+}
+
+func (c *Client) CreateList(ctx context.Context, folderID string, req *api.CreateListRequest) (*api.List, error) {
+	resp, err := c.ClientInterface.CreateList(ctx, folderID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) CreateFolderlessList(ctx context.Context, spaceID string, req *api.CreateListRequest) (*api.List, error) {
+	resp, err := c.ClientInterface.CreateFolderlessList(ctx, spaceID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) UpdateList(ctx context.Context, listID string, req *api.UpdateListRequest) (*api.List, error) {
+	resp, err := c.ClientInterface.UpdateList(ctx, listID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) DeleteList(ctx context.Context, listID string) error {
+	err := c.ClientInterface.DeleteList(ctx, listID)
+	c.invalidate(err)
+	return err
+}
+
+func (c *Client) CreateFolderFromTemplate(ctx context.Context, spaceID, templateID string, req *api.CreateFromTemplateRequest) (*api.CreateFromTemplateResponse, error) {
+	resp, err := c.ClientInterface.CreateFolderFromTemplate(ctx, spaceID, templateID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) CreateListFromFolderTemplate(ctx context.Context, folderID, templateID string, req *api.CreateFromTemplateRequest) (*api.CreateFromTemplateResponse, error) {
+	resp, err := c.ClientInterface.CreateListFromFolderTemplate(ctx, folderID, templateID, req)
+	c.invalidate(err)
+	return resp, err
+}
+
+func (c *Client) CreateListFromSpaceTemplate(ctx context.Context, spaceID, templateID string, req *api.CreateFromTemplateRequest) (*api.CreateFromTemplateResponse, error) {
+	resp, err := c.ClientInterface.CreateListFromSpaceTemplate(ctx, spaceID, templateID, req)
+	c.invalidate(err)
+	return resp, err
+}
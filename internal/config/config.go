@@ -1,11 +1,20 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
 )
 
 const (
@@ -13,6 +22,49 @@ const (
 	ConfigFileType = "yaml"
 )
 
+// Keys that can be set per profile.
+const (
+	KeyToken       = "token"
+	KeyWorkspace   = "workspace"
+	KeyDefaultList = "default_list"
+	KeyBaseURL     = "base_url"
+	KeyAuthType    = "auth_type"
+)
+
+// Other settings, read the same way: flag or CLICKUP_* environment
+// variable, then the active profile, then the top level.
+const (
+	KeyDefaultSpace  = "default_space"
+	KeyFormat        = "format"
+	KeyMaxRetries    = "max_retries"
+	KeyRetryBaseWait = "retry_base_wait"
+	KeyTimeout       = "timeout"
+	KeyCacheTTL      = "cache_ttl"
+	KeyUserAgent     = "user_agent"
+)
+
+// AuthTypeOAuth marks a token obtained with "auth login --oauth", which is
+// sent as a Bearer token.
+const AuthTypeOAuth = "oauth"
+
+const (
+	profilesKey       = "profiles"
+	currentProfileKey = "current_profile"
+	profileEnv        = "CLICKUP_PROFILE"
+)
+
+// ProfileKeys lists the settings a profile can hold.
+var ProfileKeys = []string{KeyToken, KeyWorkspace, KeyDefaultList, KeyBaseURL, KeyAuthType}
+
+var profileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// profile is the profile selected with --profile for this invocation.
+var profile string
+
+// flags holds command-line flags bound to config keys, so an explicitly set
+// flag can take precedence over profile values.
+var flags = map[string]*pflag.Flag{}
+
 func Init() {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -29,38 +81,489 @@ func Init() {
 	_ = viper.ReadInConfig()
 }
 
+// BindFlag binds a command-line flag to a config key. A flag set on the
+// command line overrides both the active profile and the top-level value.
+func BindFlag(key string, flag *pflag.Flag) {
+	flags[key] = flag
+	_ = viper.BindPFlag(key, flag)
+}
+
+// SetProfile selects the profile used for this invocation, overriding
+// CLICKUP_PROFILE and the current profile saved in the config file.
+func SetProfile(name string) {
+	profile = strings.ToLower(name)
+}
+
+// ActiveProfile returns the profile in effect: the --profile flag, then
+// CLICKUP_PROFILE, then the current profile saved with "config profile use".
+// An empty string means the top-level settings are used.
+func ActiveProfile() string {
+	if profile != "" {
+		return profile
+	}
+	if env := os.Getenv(profileEnv); env != "" {
+		return strings.ToLower(env)
+	}
+	return viper.GetString(currentProfileKey)
+}
+
+// get resolves key with precedence: flag or CLICKUP_* environment variable,
+// then the active profile, then the top-level config value.
+func get(key string) string {
+	if p := ActiveProfile(); p != "" && !overridden(key) {
+		if v := viper.GetString(profileKey(p, key)); v != "" {
+			return v
+		}
+	}
+	return viper.GetString(key)
+}
+
+// Get returns the effective value of key.
+func Get(key string) string {
+	return get(key)
+}
+
+// Lookup returns the effective value of key and where it comes from:
+// "flag", "env", "profile:<name>", "config", or "" if it is not set.
+func Lookup(key string) (value, source string) {
+	if f, ok := flags[key]; ok && f.Changed {
+		return f.Value.String(), "flag"
+	}
+	if v, ok := os.LookupEnv("CLICKUP_" + strings.ToUpper(key)); ok {
+		return v, "env"
+	}
+	if p := ActiveProfile(); p != "" {
+		if v := viper.GetString(profileKey(p, key)); v != "" {
+			return v, "profile:" + p
+		}
+	}
+	if v := viper.GetString(key); v != "" {
+		return v, "config"
+	}
+	return "", ""
+}
+
+// Set stores key in the active profile, or at the top level if no profile
+// is active. Callers validate the value.
+func Set(key, value string) error {
+	return set(key, value)
+}
+
+// Unset removes key from the active profile, or from the top level if no
+// profile is active.
+func Unset(key string) error {
+	return unset(key)
+}
+
+// overridden reports whether key was given on the command line or through
+// its CLICKUP_* environment variable.
+func overridden(key string) bool {
+	if f, ok := flags[key]; ok && f.Changed {
+		return true
+	}
+	_, ok := os.LookupEnv("CLICKUP_" + strings.ToUpper(key))
+	return ok
+}
+
+// set stores key in the active profile, or at the top level if no profile
+// is active, and writes the config file.
+func set(key, value string) error {
+	if p := ActiveProfile(); p != "" {
+		if !HasProfile(p) {
+			return fmt.Errorf("profile %q does not exist", p)
+		}
+		key = profileKey(p, key)
+	}
+	return writeValues(map[string]interface{}{key: value})
+}
+
+// unset removes key from the active profile, or from the top level if no
+// profile is active. Removing a key that is not set is not an error.
+func unset(key string) error {
+	if p := ActiveProfile(); p != "" {
+		key = profileKey(p, key)
+	}
+	if fileHasKey(key) {
+		if err := writeValues(map[string]interface{}{key: nil}); err != nil {
+			return err
+		}
+	}
+	viper.Set(key, "")
+	return nil
+}
+
+func profileKey(name, key string) string {
+	return profilesKey + "." + name + "." + key
+}
+
+// GetToken returns the API token: a --token flag or CLICKUP_TOKEN, a token
+// left in the config file by an older version, or the token saved in the
+// credential store. With a profile active, only the profile's own token is
+// used, never the top-level one, which may belong to another workspace and
+// must not be sent to the profile's base URL. Errors from the credential
+// store yield "".
 func GetToken() string {
-	return viper.GetString("token")
+	p := ActiveProfile()
+	key := KeyToken
+	if p != "" && !overridden(KeyToken) {
+		key = profileKey(p, KeyToken)
+	}
+	if token := viper.GetString(key); token != "" {
+		return token
+	}
+	store, err := Credentials()
+	if err != nil {
+		return ""
+	}
+	if token, err := store.Get(account(p)); err == nil {
+		return token
+	}
+	return ""
 }
 
 func GetWorkspace() string {
-	return viper.GetString("workspace")
+	return get(KeyWorkspace)
+}
+
+// GetDefaultList returns the list used when a command's --list is omitted.
+func GetDefaultList() string {
+	return get(KeyDefaultList)
+}
+
+// GetBaseURL returns the API base URL override, or "" for the default.
+func GetBaseURL() string {
+	return get(KeyBaseURL)
+}
+
+// GetDefaultSpace returns the space used when a command's --space is omitted.
+func GetDefaultSpace() string {
+	return get(KeyDefaultSpace)
+}
+
+// GetMaxRetries returns the configured number of retries, and false if it
+// is unset or not a non-negative integer.
+func GetMaxRetries() (int, bool) {
+	n, err := strconv.Atoi(get(KeyMaxRetries))
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// GetRetryBaseWait returns the configured base wait between retries, or 0
+// if it is unset or invalid.
+func GetRetryBaseWait() time.Duration {
+	return getDuration(KeyRetryBaseWait)
+}
+
+// GetTimeout returns the configured HTTP request timeout, or 0 if it is
+// unset or invalid.
+func GetTimeout() time.Duration {
+	return getDuration(KeyTimeout)
+}
+
+// GetUserAgent returns the User-Agent override, or "" for the default.
+func GetUserAgent() string {
+	return get(KeyUserAgent)
+}
+
+// GetCacheTTL returns how long cached spaces, folders, lists and members
+// stay fresh, or 0 if it is unset or invalid.
+func GetCacheTTL() time.Duration {
+	return getDuration(KeyCacheTTL)
+}
+
+func getDuration(key string) time.Duration {
+	d, err := time.ParseDuration(get(key))
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
 }
 
+// SetToken saves a personal API token in the credential store for the
+// active profile and removes any plaintext copy from the config file.
 func SetToken(token string) error {
-	viper.Set("token", token)
-	return writeConfig()
+	store, err := Credentials()
+	if err != nil {
+		return err
+	}
+	p := ActiveProfile()
+	if p != "" && !HasProfile(p) {
+		return fmt.Errorf("profile %q does not exist", p)
+	}
+	if err := store.Set(account(p), token); err != nil {
+		return err
+	}
+	// Drop any plaintext copy left by an older version.
+	if err := unset(KeyToken); err != nil {
+		return err
+	}
+	return unset(KeyAuthType)
+}
+
+// SetOAuthToken saves an OAuth access token like SetToken and records that
+// it is sent as a Bearer token.
+func SetOAuthToken(token string) error {
+	if err := SetToken(token); err != nil {
+		return err
+	}
+	return set(KeyAuthType, AuthTypeOAuth)
+}
+
+// DeleteToken removes the active profile's token from the credential store
+// and the config file, along with its auth type. A token that was not saved
+// is not an error.
+func DeleteToken() error {
+	store, err := Credentials()
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(account(ActiveProfile())); err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return err
+	}
+	if err := unset(KeyToken); err != nil {
+		return err
+	}
+	return unset(KeyAuthType)
+}
+
+// GetAuthType returns how the token is sent: AuthTypeOAuth for an OAuth
+// access token, otherwise a personal API token.
+func GetAuthType() string {
+	return get(KeyAuthType)
 }
 
 func SetWorkspace(workspace string) error {
-	viper.Set("workspace", workspace)
-	return writeConfig()
+	return set(KeyWorkspace, workspace)
 }
 
-func writeConfig() error {
-	home, err := os.UserHomeDir()
+// Profile is a named set of credentials and defaults. Token is only read
+// and written through the credential store.
+type Profile struct {
+	Name        string
+	Token       string
+	Workspace   string
+	DefaultList string
+	BaseURL     string
+}
+
+// ValidateProfileName checks that name can be used as a profile name.
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// HasProfile reports whether a profile named name exists in the config file.
+func HasProfile(name string) bool {
+	return viper.IsSet(profilesKey + "." + name)
+}
+
+// Profiles returns all profiles in the config file, sorted by name.
+func Profiles() []Profile {
+	names := make([]string, 0)
+	for name := range viper.GetStringMap(profilesKey) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, Profile{
+			Name:        name,
+			Token:       profileToken(name),
+			Workspace:   viper.GetString(profileKey(name, KeyWorkspace)),
+			DefaultList: viper.GetString(profileKey(name, KeyDefaultList)),
+			BaseURL:     viper.GetString(profileKey(name, KeyBaseURL)),
+		})
+	}
+	return profiles
+}
+
+// profileToken returns the token saved for a profile, or "" if there is
+// none or the credential store cannot be read.
+func profileToken(name string) string {
+	if token := viper.GetString(profileKey(name, KeyToken)); token != "" {
+		return token
+	}
+	store, err := Credentials()
 	if err != nil {
-		return fmt.Errorf("cannot find home directory: %w", err)
+		return ""
+	}
+	token, _ := store.Get(name)
+	return token
+}
+
+// SaveProfile creates or updates a profile. Empty fields of an existing
+// profile are left unchanged. The token goes to the credential store.
+func SaveProfile(p Profile) error {
+	if err := ValidateProfileName(p.Name); err != nil {
+		return err
+	}
+	if p.Token != "" {
+		store, err := Credentials()
+		if err != nil {
+			return err
+		}
+		if err := store.Set(p.Name, p.Token); err != nil {
+			return err
+		}
+	}
+	values := map[string]interface{}{
+		profilesKey + "." + p.Name: map[string]interface{}{},
+	}
+	for k, v := range map[string]string{
+		KeyWorkspace:   p.Workspace,
+		KeyDefaultList: p.DefaultList,
+		KeyBaseURL:     p.BaseURL,
+	} {
+		if v != "" {
+			values[profileKey(p.Name, k)] = v
+		}
 	}
+	if p.Token != "" && fileHasKey(profileKey(p.Name, KeyToken)) {
+		values[profileKey(p.Name, KeyToken)] = nil
+	}
+	return writeValues(values)
+}
 
-	configPath := filepath.Join(home, ConfigFileName+"."+ConfigFileType)
+// UseProfile saves name as the current profile. An empty name switches back
+// to the top-level settings.
+func UseProfile(name string) error {
+	if name != "" && !HasProfile(name) {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	return writeValues(map[string]interface{}{currentProfileKey: name})
+}
 
-	if err := viper.WriteConfigAs(configPath); err != nil {
-		return fmt.Errorf("failed to write config: %w", err)
+// RemoveProfile deletes a profile and its stored token. If it was the
+// current profile, the top-level settings become current again.
+func RemoveProfile(name string) error {
+	if !HasProfile(name) {
+		return fmt.Errorf("profile %q does not exist", name)
 	}
+	if store, err := Credentials(); err == nil {
+		if err := store.Delete(name); err != nil && !errors.Is(err, ErrCredentialNotFound) {
+			return err
+		}
+	}
+	values := map[string]interface{}{profilesKey + "." + name: nil}
+	if viper.GetString(currentProfileKey) == name {
+		values[currentProfileKey] = ""
+	}
+	if err := writeValues(values); err != nil {
+		return err
+	}
+	profiles := viper.GetStringMap(profilesKey)
+	delete(profiles, name)
+	viper.Set(profilesKey, profiles)
 	return nil
 }
 
+// writeValues applies values to the config file, keyed by dotted path; a nil
+// value removes the key. Only what is already in the file and the given
+// values are written, so flag and environment overrides such as --token
+// never end up on disk. The values are also applied to the running config.
+func writeValues(values map[string]interface{}) error {
+	path := ConfigFilePath()
+	settings, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	// Parents sort before their children, so a profile is created before
+	// its settings are filled in.
+	sort.Strings(keys)
+	for _, key := range keys {
+		setPath(settings, strings.Split(key, "."), values[key])
+		if values[key] != nil {
+			viper.Set(key, values[key])
+		}
+	}
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return os.Chmod(path, 0o600)
+}
+
+// readFile returns the settings in the config file at path, or an empty map
+// if it does not exist yet.
+func readFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	settings := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return settings, nil
+}
+
+// fileHasKey reports whether the config file sets the dotted key.
+func fileHasKey(key string) bool {
+	settings, err := readFile(ConfigFilePath())
+	if err != nil {
+		return false
+	}
+	m := settings
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		val, ok := m[part]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return true
+		}
+		if m, ok = val.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+// setPath sets or, for a nil value, deletes a nested key.
+func setPath(m map[string]interface{}, path []string, val interface{}) {
+	for _, part := range path[:len(path)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			if val == nil {
+				return
+			}
+			next = map[string]interface{}{}
+			m[part] = next
+		}
+		m = next
+	}
+	last := path[len(path)-1]
+	if val == nil {
+		delete(m, last)
+		return
+	}
+	if sub, ok := val.(map[string]interface{}); ok {
+		if existing, ok := m[last].(map[string]interface{}); ok {
+			for k, v := range sub {
+				existing[k] = v
+			}
+			return
+		}
+	}
+	m[last] = val
+}
+
 func ConfigFilePath() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ConfigFileName+"."+ConfigFileType)
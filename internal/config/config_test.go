@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -19,9 +20,29 @@ func setupTestConfig(t *testing.T) (cleanup func()) {
 	viper.SetConfigType(ConfigFileType)
 	viper.AddConfigPath(tmpDir)
 
+	// A cheap key derivation keeps the encrypted file store fast in tests.
+	t.Setenv("CLICKUP_PASSPHRASE", "test passphrase")
+	SetCredentialStore(&fileStore{path: filepath.Join(tmpDir, CredentialsFileName), iterations: 1})
+
 	return func() {
 		os.Setenv("HOME", origHome)
 		viper.Reset()
+		SetCredentialStore(nil)
+	}
+}
+
+// reloadConfig discards in-memory settings and re-reads the config file the
+// way Init does.
+func reloadConfig(t *testing.T) {
+	t.Helper()
+	viper.Reset()
+	viper.SetConfigName(ConfigFileName)
+	viper.SetConfigType(ConfigFileType)
+	viper.AddConfigPath(os.Getenv("HOME"))
+	viper.SetEnvPrefix("CLICKUP")
+	viper.AutomaticEnv()
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("failed to re-read config: %v", err)
 	}
 }
 
@@ -122,3 +143,166 @@ func TestConfigFilePath(t *testing.T) {
 		t.Errorf("expected .yaml extension, got %s", filepath.Ext(path))
 	}
 }
+
+func TestProfiles(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+	defer SetProfile("")
+
+	if err := SetToken("pk_default"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProfile(Profile{Name: "acme", Token: "pk_acme", Workspace: "111", DefaultList: "900"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProfile(Profile{Name: "own", Token: "pk_own", BaseURL: "http://localhost:8080"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProfile(Profile{Name: "Bad.Name"}); err == nil {
+		t.Error("expected error for invalid profile name")
+	}
+
+	if got := GetToken(); got != "pk_default" {
+		t.Errorf("without a profile expected top-level token, got %q", got)
+	}
+
+	if err := UseProfile("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetToken(); got != "pk_acme" {
+		t.Errorf("expected acme token, got %q", got)
+	}
+	if got := GetDefaultList(); got != "900" {
+		t.Errorf("expected acme default list, got %q", got)
+	}
+
+	// --profile overrides the current profile; unset values fall back to
+	// the top level.
+	SetProfile("own")
+	if got := GetToken(); got != "pk_own" {
+		t.Errorf("expected own token, got %q", got)
+	}
+	if got := GetBaseURL(); got != "http://localhost:8080" {
+		t.Errorf("expected own base URL, got %q", got)
+	}
+
+	// Writes go to the active profile.
+	if err := SetWorkspace("222"); err != nil {
+		t.Fatal(err)
+	}
+	if got := viper.GetString("profiles.own.workspace"); got != "222" {
+		t.Errorf("expected workspace saved in profile, got %q", got)
+	}
+	if got := viper.GetString("workspace"); got != "" {
+		t.Errorf("top-level workspace changed to %q", got)
+	}
+
+	// A profile without a token of its own does not borrow the default one.
+	if err := SaveProfile(Profile{Name: "mock", BaseURL: "http://localhost:9999"}); err != nil {
+		t.Fatal(err)
+	}
+	SetProfile("mock")
+	if got := GetToken(); got != "" {
+		t.Errorf("expected no token for a profile without one, got %q", got)
+	}
+	SetProfile("own")
+
+	// Environment variables still override profile values.
+	reloadConfig(t)
+	t.Setenv("CLICKUP_TOKEN", "pk_env")
+	if got := GetToken(); got != "pk_env" {
+		t.Errorf("expected env token, got %q", got)
+	}
+}
+
+func TestRemoveProfile(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	if err := SaveProfile(Profile{Name: "acme", Token: "pk_acme"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProfile(Profile{Name: "other", Token: "pk_other"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := UseProfile("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveProfile("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveProfile("acme"); err == nil {
+		t.Error("expected error removing a missing profile")
+	}
+	if err := UseProfile("missing"); err == nil {
+		t.Error("expected error using a missing profile")
+	}
+	if got := ActiveProfile(); got != "" {
+		t.Errorf("expected no active profile, got %q", got)
+	}
+
+	reloadConfig(t)
+	profiles := Profiles()
+	if len(profiles) != 1 || profiles[0].Name != "other" || profiles[0].Token != "pk_other" {
+		t.Errorf("unexpected profiles after reload: %+v", profiles)
+	}
+}
+
+func TestLookupSources(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+	defer SetProfile("")
+
+	if err := Set(KeyTimeout, "20s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(KeyMaxRetries, "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProfile(Profile{Name: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+	SetProfile("acme")
+	if err := Set(KeyMaxRetries, "5"); err != nil {
+		t.Fatal(err)
+	}
+	reloadConfig(t)
+
+	tests := []struct {
+		key, value, source string
+	}{
+		{KeyTimeout, "20s", "config"},
+		{KeyMaxRetries, "5", "profile:acme"},
+		{KeyDefaultSpace, "", ""},
+	}
+	for _, tt := range tests {
+		value, source := Lookup(tt.key)
+		if value != tt.value || source != tt.source {
+			t.Errorf("Lookup(%s) = %q, %q; want %q, %q", tt.key, value, source, tt.value, tt.source)
+		}
+	}
+	if got := GetTimeout(); got != 20*time.Second {
+		t.Errorf("GetTimeout = %v", got)
+	}
+	if n, ok := GetMaxRetries(); !ok || n != 5 {
+		t.Errorf("GetMaxRetries = %d, %v", n, ok)
+	}
+
+	t.Setenv("CLICKUP_MAX_RETRIES", "bogus")
+	if value, source := Lookup(KeyMaxRetries); value != "bogus" || source != "env" {
+		t.Errorf("expected env source, got %q, %q", value, source)
+	}
+	if _, ok := GetMaxRetries(); ok {
+		t.Error("expected invalid max_retries to be ignored")
+	}
+
+	if err := Unset(KeyMaxRetries); err != nil {
+		t.Fatal(err)
+	}
+	reloadConfig(t)
+	t.Setenv("CLICKUP_MAX_RETRIES", "")
+	os.Unsetenv("CLICKUP_MAX_RETRIES")
+	if value, source := Lookup(KeyMaxRetries); value != "2" || source != "config" {
+		t.Errorf("after unset expected top-level value, got %q, %q", value, source)
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// KeyCredentialStore selects where tokens are saved: "auto" (the default)
+// uses the OS keyring when one is available and the encrypted file
+// otherwise; "keyring" and "file" force a backend.
+const KeyCredentialStore = "credential_store"
+
+const (
+	// CredentialsFileName is the encrypted token file in the home directory.
+	CredentialsFileName = ".clickup-cli.credentials"
+
+	// DefaultAccount is the credential account used when no profile is active.
+	DefaultAccount = "default"
+
+	credentialService = "clickup-cli"
+	passphraseEnv     = "CLICKUP_PASSPHRASE"
+)
+
+// ErrCredentialNotFound is returned by CredentialStore.Get and Delete when
+// no secret is saved for the account.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore keeps secrets such as API tokens out of the plaintext
+// config file. Accounts are profile names, or DefaultAccount.
+type CredentialStore interface {
+	// Name identifies the backend ("keyring" or "file").
+	Name() string
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// PassphraseFunc asks the user for the passphrase of the encrypted
+// credentials file. confirm is true when the file is being created, so the
+// caller can ask twice.
+type PassphraseFunc func(confirm bool) (string, error)
+
+var (
+	credentialStore  CredentialStore
+	passphrasePrompt PassphraseFunc
+)
+
+// SetPassphrasePrompt sets how the encrypted file backend asks for its
+// passphrase when CLICKUP_PASSPHRASE is not set.
+func SetPassphrasePrompt(fn PassphraseFunc) {
+	passphrasePrompt = fn
+}
+
+// SetCredentialStore overrides the credential store, mainly for tests. nil
+// restores selection from the credential_store setting.
+func SetCredentialStore(store CredentialStore) {
+	credentialStore = store
+}
+
+// Credentials returns the credential store selected by the credential_store
+// setting.
+func Credentials() (CredentialStore, error) {
+	if credentialStore != nil {
+		return credentialStore, nil
+	}
+	store, err := openCredentialStore(strings.ToLower(viper.GetString(KeyCredentialStore)))
+	if err != nil {
+		return nil, err
+	}
+	credentialStore = store
+	return store, nil
+}
+
+func openCredentialStore(backend string) (CredentialStore, error) {
+	switch backend {
+	case "", "auto":
+		if keyringAvailable() {
+			return keyringStore{}, nil
+		}
+		return newFileStore(CredentialsFilePath()), nil
+	case "keyring":
+		if !keyringAvailable() {
+			return nil, errors.New("no OS keyring available; set credential_store to file")
+		}
+		return keyringStore{}, nil
+	case "file":
+		return newFileStore(CredentialsFilePath()), nil
+	default:
+		return nil, fmt.Errorf("unknown credential_store %q: use auto, keyring or file", backend)
+	}
+}
+
+// CredentialsFilePath returns the path of the encrypted credentials file.
+func CredentialsFilePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, CredentialsFileName)
+}
+
+// account maps a profile name to its credential account.
+func account(profile string) string {
+	if profile == "" {
+		return DefaultAccount
+	}
+	return profile
+}
+
+// passphrase returns CLICKUP_PASSPHRASE or asks through the prompt.
+func passphrase(confirm bool) (string, error) {
+	if p := os.Getenv(passphraseEnv); p != "" {
+		return p, nil
+	}
+	if passphrasePrompt == nil {
+		return "", fmt.Errorf("a passphrase is required for %s; set %s", CredentialsFileName, passphraseEnv)
+	}
+	p, err := passphrasePrompt(confirm)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("passphrase cannot be empty")
+	}
+	return p, nil
+}
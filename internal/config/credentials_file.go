@@ -0,0 +1,211 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+const (
+	credentialsFileVersion = 1
+	defaultKDFIterations   = 600000
+	maxKDFIterations       = 10 * defaultKDFIterations
+	saltSize               = 16
+	keySize                = 32
+)
+
+// credentialsFile is the on-disk form of the encrypted store. Data is the
+// AES-256-GCM encryption of a JSON object mapping accounts to secrets, with
+// the key derived from the passphrase by PBKDF2-HMAC-SHA256.
+type credentialsFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// fileStore keeps secrets in a passphrase-encrypted file readable only by
+// the owner. The passphrase is asked for at most once per process.
+type fileStore struct {
+	path string
+	// iterations is the PBKDF2 count for a new file, and the least a file
+	// read back may use.
+	iterations int
+
+	key     []byte
+	salt    []byte
+	secrets map[string]string
+}
+
+func newFileStore(path string) *fileStore {
+	return &fileStore{path: path, iterations: defaultKDFIterations}
+}
+
+func (s *fileStore) Name() string { return "file" }
+
+func (s *fileStore) Get(account string) (string, error) {
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	secret, ok := s.secrets[account]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (s *fileStore) Set(account, secret string) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.secrets[account] = secret
+	return s.save()
+}
+
+func (s *fileStore) Delete(account string) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.secrets[account]; !ok {
+		return ErrCredentialNotFound
+	}
+	delete(s.secrets, account)
+	return s.save()
+}
+
+// load reads and decrypts the file, asking for the passphrase. A missing
+// file is an empty store; its passphrase is asked for on the first save.
+func (s *fileStore) load() error {
+	if s.secrets != nil {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.secrets = map[string]string{}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+	var f credentialsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if f.Version != credentialsFileVersion || f.KDF != "pbkdf2-sha256" {
+		return fmt.Errorf("unsupported credentials file %s (version %d, kdf %q)", s.path, f.Version, f.KDF)
+	}
+	// A lowered count would quietly weaken the key from then on, and a huge
+	// one would hang every command that reads a token.
+	if f.Iterations < s.iterations || f.Iterations > maxKDFIterations {
+		return fmt.Errorf("invalid credentials file %s: %d key derivation iterations, expected %d to %d", s.path, f.Iterations, s.iterations, maxKDFIterations)
+	}
+	pass, err := passphrase(false)
+	if err != nil {
+		return err
+	}
+	key := pbkdf2SHA256([]byte(pass), f.Salt, f.Iterations, keySize)
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return errors.New("failed to decrypt credentials: wrong passphrase or corrupted file")
+	}
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+	s.key, s.salt, s.iterations, s.secrets = key, f.Salt, f.Iterations, secrets
+	return nil
+}
+
+// save encrypts the secrets with a fresh nonce and writes the file with
+// owner-only permissions.
+func (s *fileStore) save() error {
+	if s.key == nil {
+		pass, err := passphrase(true)
+		if err != nil {
+			return err
+		}
+		s.salt = make([]byte, saltSize)
+		if _, err := rand.Read(s.salt); err != nil {
+			return err
+		}
+		s.key = pbkdf2SHA256([]byte(pass), s.salt, s.iterations, keySize)
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(s.secrets)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(credentialsFile{
+		Version:    credentialsFileVersion,
+		KDF:        "pbkdf2-sha256",
+		Iterations: s.iterations,
+		Salt:       s.salt,
+		Nonce:      nonce,
+		Data:       gcm.Seal(nil, nonce, plain, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file.
+	return os.Chmod(s.path, 0o600)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2SHA256 derives a key as specified in RFC 8018, section 5.2.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+	dk := make([]byte, 0, blocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		var idx [4]byte
+		binary.BigEndian.PutUint32(idx[:], uint32(block))
+		prf.Write(idx[:])
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:keyLen]
+}
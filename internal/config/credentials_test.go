@@ -0,0 +1,242 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// RFC 7914, section 11.
+	got := hex.EncodeToString(pbkdf2SHA256([]byte("passwd"), []byte("salt"), 1, 64))
+	want := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	got = hex.EncodeToString(pbkdf2SHA256([]byte("Password"), []byte("NaCl"), 80000, 64))
+	want = "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"
+	if got != want {
+		t.Errorf("c=80000: got %s, want %s", got, want)
+	}
+}
+
+func TestFileStoreIterations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CredentialsFileName)
+	t.Setenv("CLICKUP_PASSPHRASE", "correct horse")
+	if err := (&fileStore{path: path, iterations: 1}).Set(DefaultAccount, "pk_weak"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&fileStore{path: path, iterations: 10}).Get(DefaultAccount); err == nil || !strings.Contains(err.Error(), "iterations") {
+		t.Errorf("expected a file with fewer iterations to be rejected, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), `"iterations": 1,`, `"iterations": 2000000000,`, 1))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&fileStore{path: path, iterations: 1}).Get(DefaultAccount); err == nil || !strings.Contains(err.Error(), "iterations") {
+		t.Errorf("expected a huge iteration count to be rejected, got %v", err)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CredentialsFileName)
+	t.Setenv("CLICKUP_PASSPHRASE", "correct horse")
+
+	store := &fileStore{path: path, iterations: 10}
+	if _, err := store.Get(DefaultAccount); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("expected ErrCredentialNotFound from empty store, got %v", err)
+	}
+	if err := store.Set(DefaultAccount, "pk_secret_token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("acme", "pk_acme"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected mode 0600, got %o", perm)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "pk_secret_token") {
+		t.Error("token written in plaintext")
+	}
+
+	// A new store instance decrypts with the same passphrase.
+	reopened := &fileStore{path: path}
+	if got, err := reopened.Get(DefaultAccount); err != nil || got != "pk_secret_token" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if err := reopened.Delete("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Delete("acme"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("expected ErrCredentialNotFound, got %v", err)
+	}
+
+	t.Setenv("CLICKUP_PASSPHRASE", "wrong")
+	if _, err := (&fileStore{path: path}).Get(DefaultAccount); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("expected wrong passphrase error, got %v", err)
+	}
+}
+
+func TestFileStorePrompt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CredentialsFileName)
+	t.Setenv("CLICKUP_PASSPHRASE", "")
+	defer SetPassphrasePrompt(nil)
+
+	if err := (&fileStore{path: path, iterations: 1}).Set(DefaultAccount, "x"); err == nil {
+		t.Error("expected error without a passphrase source")
+	}
+
+	var confirms []bool
+	SetPassphrasePrompt(func(confirm bool) (string, error) {
+		confirms = append(confirms, confirm)
+		return "prompted", nil
+	})
+	if err := (&fileStore{path: path, iterations: 1}).Set(DefaultAccount, "pk_prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := (&fileStore{path: path}).Get(DefaultAccount); err != nil || got != "pk_prompt" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if len(confirms) != 2 || !confirms[0] || confirms[1] {
+		t.Errorf("expected a confirmed prompt on create then a single prompt on read, got %v", confirms)
+	}
+}
+
+func TestKeyringStore(t *testing.T) {
+	origRun, origGOOS := runKeyring, keyringGOOS
+	defer func() { runKeyring, keyringGOOS = origRun, origGOOS }()
+
+	saved := map[string]string{}
+	var calls []string
+	runKeyring = func(stdin, name string, args ...string) (string, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		acct := args[len(args)-1]
+		switch {
+		case name == "secret-tool" && args[0] == "store":
+			saved[acct] = stdin
+		case name == "secret-tool" && args[0] == "lookup":
+			if v, ok := saved[acct]; ok {
+				return v, nil
+			}
+			return "", errKeyringExit
+		case name == "secret-tool" && args[0] == "clear":
+			delete(saved, acct)
+		case name == "security" && args[0] == "-i":
+			// add-generic-password -U -s "clickup-cli" -a "ACCOUNT" -X HEX
+			f := strings.Fields(stdin)
+			secret, _ := hex.DecodeString(f[len(f)-1])
+			saved["darwin:"+strings.Trim(f[len(f)-3], `"`)] = string(secret)
+		case name == "security" && args[0] == "find-generic-password":
+			if v, ok := saved["darwin:"+args[4]]; ok {
+				return v + "\n", nil
+			}
+			return "", errKeyringExit
+		}
+		return "", nil
+	}
+
+	keyringGOOS = "linux"
+	store := keyringStore{}
+	if err := store.Set("acme", "pk_acme"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Get("acme"); err != nil || got != "pk_acme" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	// The secret is passed on stdin, never as an argument.
+	if strings.Contains(calls[0], "pk_acme") {
+		t.Errorf("secret passed on the command line: %s", calls[0])
+	}
+	if err := store.Delete("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("acme"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("expected ErrCredentialNotFound after delete, got %v", err)
+	}
+
+	keyringGOOS = "darwin"
+	if _, err := store.Get("acme"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("expected ErrCredentialNotFound on darwin, got %v", err)
+	}
+	if last := calls[len(calls)-1]; last != "security find-generic-password -s clickup-cli -a acme -w" {
+		t.Errorf("unexpected darwin call: %s", last)
+	}
+	calls = nil
+	if err := store.Set("acme", "pk_acme"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Get("acme"); err != nil || got != "pk_acme" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if calls[0] != "security -i" {
+		t.Errorf("expected the command on stdin, got %s", calls[0])
+	}
+}
+
+func TestOpenCredentialStore(t *testing.T) {
+	if _, err := openCredentialStore("vault"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+	store, err := openCredentialStore("file")
+	if err != nil || store.Name() != "file" {
+		t.Errorf("expected file store, got %v, %v", store, err)
+	}
+}
+
+func TestSetTokenMigratesPlaintext(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	// A config file written by an older version.
+	path := ConfigFilePath()
+	if err := os.WriteFile(path, []byte("token: pk_old\nworkspace: \"123\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reloadConfig(t)
+	if got := GetToken(); got != "pk_old" {
+		t.Fatalf("expected plaintext token to be read, got %q", got)
+	}
+
+	if err := SetToken("pk_new"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "pk_") {
+		t.Errorf("config file still holds a token:\n%s", data)
+	}
+	if !strings.Contains(string(data), "workspace") {
+		t.Errorf("other settings lost:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+
+	reloadConfig(t)
+	if got := GetToken(); got != "pk_new" {
+		t.Errorf("expected stored token, got %q", got)
+	}
+
+	if err := DeleteToken(); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteToken(); err != nil {
+		t.Errorf("deleting a missing token should succeed, got %v", err)
+	}
+	if got := GetToken(); got != "" {
+		t.Errorf("expected no token after delete, got %q", got)
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringStore saves secrets in the OS keyring through the platform's
+// command-line tool: secret-tool (Secret Service) on Linux and security
+// (Keychain) on macOS.
+type keyringStore struct{}
+
+// runKeyring runs a keyring tool with stdin as input. It can be replaced in
+// tests.
+var runKeyring = func(stdin, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() == 0 {
+			return "", errKeyringExit
+		}
+		return "", fmt.Errorf("%s: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// errKeyringExit is a non-zero exit without output, which the tools use to
+// report a missing item.
+var errKeyringExit = errors.New("keyring tool exited without output")
+
+// keyringGOOS is runtime.GOOS, replaceable in tests.
+var keyringGOOS = runtime.GOOS
+
+func keyringAvailable() bool {
+	switch keyringGOOS {
+	case "linux", "freebsd", "openbsd":
+		_, err := exec.LookPath("secret-tool")
+		return err == nil && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != ""
+	case "darwin":
+		_, err := exec.LookPath("security")
+		return err == nil
+	}
+	return false
+}
+
+func (keyringStore) Name() string { return "keyring" }
+
+func (keyringStore) Get(account string) (string, error) {
+	var out string
+	var err error
+	if keyringGOOS == "darwin" {
+		out, err = runKeyring("", "security", "find-generic-password", "-s", credentialService, "-a", account, "-w")
+	} else {
+		out, err = runKeyring("", "secret-tool", "lookup", "service", credentialService, "account", account)
+	}
+	if errors.Is(err, errKeyringExit) || (err == nil && out == "") {
+		return "", ErrCredentialNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// Set passes the secret on stdin so that it never shows up in the process
+// list. security only takes a password as an argument, so on macOS the
+// command is given to its interactive mode on stdin instead, with the secret
+// hex-encoded, and the saved item is read back to confirm the write.
+func (s keyringStore) Set(account, secret string) error {
+	if keyringGOOS != "darwin" {
+		_, err := runKeyring(secret, "secret-tool", "store", "--label", credentialService+" ("+account+")", "service", credentialService, "account", account)
+		return err
+	}
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+		securityQuote(credentialService), securityQuote(account), hex.EncodeToString([]byte(secret)))
+	if _, err := runKeyring(command, "security", "-i"); err != nil {
+		return err
+	}
+	// Interactive mode exits 0 even when a command fails.
+	if saved, err := s.Get(account); err != nil || saved != secret {
+		return fmt.Errorf("security: could not save the %s credential in the keychain", account)
+	}
+	return nil
+}
+
+// securityQuote quotes an argument for security's interactive mode.
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func (s keyringStore) Delete(account string) error {
+	if _, err := s.Get(account); err != nil {
+		return err
+	}
+	var err error
+	if keyringGOOS == "darwin" {
+		_, err = runKeyring("", "security", "delete-generic-password", "-s", credentialService, "-a", account)
+	} else {
+		_, err = runKeyring("", "secret-tool", "clear", "service", credentialService, "account", account)
+	}
+	return err
+}
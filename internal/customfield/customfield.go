@@ -0,0 +1,686 @@
+// Package customfield converts between the values people type for ClickUp
+// custom fields ("High", "2025-01-31", "alice,-bob") and the JSON the API
+// stores, keyed on each field's type and type config.
+package customfield
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/resolve"
+)
+
+// Field types with a codec.
+const (
+	TypeShortText         = "short_text"
+	TypeText              = "text"
+	TypeURL               = "url"
+	TypeEmail             = "email"
+	TypePhone             = "phone"
+	TypeCheckbox          = "checkbox"
+	TypeNumber            = "number"
+	TypeCurrency          = "currency"
+	TypeEmoji             = "emoji"
+	TypeDate              = "date"
+	TypeDropDown          = "drop_down"
+	TypeLabels            = "labels"
+	TypeUsers             = "users"
+	TypeTasks             = "tasks"
+	TypeListRelationship  = "list_relationship"
+	TypeManualProgress    = "manual_progress"
+	TypeAutomaticProgress = "automatic_progress"
+	TypeLocation          = "location"
+	TypeFormula           = "formula"
+)
+
+// Error reports a value that does not fit its field. Choices lists the
+// valid values when the field has a fixed set, such as dropdown options.
+type Error struct {
+	Field   string
+	Type    string
+	Value   string
+	Reason  string
+	Choices []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("custom field %q (%s): %s", e.Field, e.Type, e.Reason)
+}
+
+func invalid(format string, args ...interface{}) *Error {
+	return &Error{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Option is a dropdown or label option.
+type Option struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name,omitempty"`
+	Label      string      `json:"label,omitempty"`
+	Color      string      `json:"color,omitempty"`
+	OrderIndex api.FlexInt `json:"orderindex"`
+}
+
+// title is how people refer to the option: labels have a label, dropdown
+// options a name.
+func (o Option) title() string {
+	if o.Label != "" {
+		return o.Label
+	}
+	return o.Name
+}
+
+// Config is the part of a field's type_config the codecs read.
+type Config struct {
+	Options   []Option       `json:"options,omitempty"`
+	Precision *int           `json:"precision,omitempty"`
+	Count     int            `json:"count,omitempty"`
+	Start     *api.FlexFloat `json:"start,omitempty"`
+	End       *api.FlexFloat `json:"end,omitempty"`
+}
+
+// TypeConfig decodes f's type config. A missing or unexpected config reads
+// as empty.
+func TypeConfig(f api.CustomField) Config {
+	var cfg Config
+	if data, err := json.Marshal(f.TypeConfig); err == nil {
+		_ = json.Unmarshal(data, &cfg)
+	}
+	return cfg
+}
+
+// Change adds and removes the users or tasks of a relationship field.
+type Change[T any] struct {
+	Add []T `json:"add"`
+	Rem []T `json:"rem"`
+}
+
+// Progress is the value of a manual progress field.
+type Progress struct {
+	Current float64 `json:"current"`
+}
+
+// Location is the value of a location field.
+type Location struct {
+	Location         LatLng `json:"location"`
+	FormattedAddress string `json:"formatted_address"`
+}
+
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Codec encodes and decodes custom field values. The zero value accepts
+// numeric user IDs and dates as YYYY-MM-DD, RFC 3339 or Unix milliseconds.
+type Codec struct {
+	// ParseDate reads the value of a date field. A result with a time of
+	// day other than midnight is sent with value_options.time set.
+	ParseDate func(s string) (time.Time, error)
+
+	// ResolveUser turns a user reference, such as a username, into an ID.
+	// Its errors are returned as they are.
+	ResolveUser func(ref string) (int, error)
+}
+
+type codec struct {
+	encode func(c *Codec, cfg Config, s string) (interface{}, *api.CustomFieldValueOptions, error)
+	decode func(cfg Config, v interface{}) string
+	// object checks a value given as a JSON object, for the types whose
+	// value is one.
+	object func(cfg Config, data []byte) (interface{}, error)
+}
+
+var codecs = map[string]codec{
+	TypeShortText:         {encode: encodeText, decode: decodeScalar},
+	TypeText:              {encode: encodeText, decode: decodeScalar},
+	TypeURL:               {encode: encodeURL, decode: decodeScalar},
+	TypeEmail:             {encode: encodeEmail, decode: decodeScalar},
+	TypePhone:             {encode: encodePhone, decode: decodeScalar},
+	TypeCheckbox:          {encode: encodeCheckbox, decode: decodeScalar},
+	TypeNumber:            {encode: encodeNumber, decode: decodeScalar},
+	TypeCurrency:          {encode: encodeCurrency, decode: decodeScalar},
+	TypeEmoji:             {encode: encodeEmoji, decode: decodeScalar},
+	TypeDate:              {encode: encodeDate, decode: decodeDate},
+	TypeDropDown:          {encode: encodeDropDown, decode: decodeDropDown},
+	TypeLabels:            {encode: encodeLabels, decode: decodeLabels},
+	TypeUsers:             {encode: encodeUsers, decode: decodeIDs, object: objectChange[int]},
+	TypeTasks:             {encode: encodeTasks, decode: decodeIDs, object: objectChange[string]},
+	TypeListRelationship:  {encode: encodeTasks, decode: decodeIDs, object: objectChange[string]},
+	TypeManualProgress:    {encode: encodeProgress, decode: decodeProgress, object: objectProgress},
+	TypeAutomaticProgress: {decode: decodeProgress},
+	TypeLocation:          {encode: encodeLocation, decode: decodeLocation, object: objectLocation},
+	TypeFormula:           {decode: decodeScalar},
+}
+
+// Encode turns s into the request that sets f to it, validating it against
+// f's type and type config. Users, tasks, relationship, progress and
+// location fields also take their value as a JSON object, which is checked
+// the same way. A value of a type without a codec is sent as it is, parsed
+// as JSON when it is valid JSON. A quoted JSON string is unquoted first.
+// Invalid values return an *Error.
+func (c *Codec) Encode(f api.CustomField, s string) (*api.SetCustomFieldRequest, error) {
+	if strings.HasPrefix(s, `"`) {
+		var unquoted string
+		if json.Unmarshal([]byte(s), &unquoted) == nil {
+			s = unquoted
+		}
+	}
+	fc, ok := codecs[f.Type]
+	if !ok {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			v = s
+		}
+		return &api.SetCustomFieldRequest{Value: v}, nil
+	}
+
+	var err error
+	var v interface{}
+	var opts *api.CustomFieldValueOptions
+	switch {
+	case fc.encode == nil:
+		err = invalid("%s fields are computed by ClickUp and cannot be set", f.Type)
+	case strings.TrimSpace(s) == "":
+		err = invalid("empty value; use custom-field remove to clear it")
+	case fc.object != nil && strings.HasPrefix(strings.TrimSpace(s), "{"):
+		v, err = fc.object(TypeConfig(f), []byte(strings.TrimSpace(s)))
+	default:
+		v, opts, err = fc.encode(c, TypeConfig(f), strings.TrimSpace(s))
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		e.Field, e.Type, e.Value = f.Name, f.Type, s
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &api.SetCustomFieldRequest{Value: v, ValueOptions: opts}, nil
+}
+
+// Decode returns f's value in the form Encode accepts: option names for
+// dropdowns and labels, dates as YYYY-MM-DD or RFC 3339 in local time, IDs
+// for users and tasks. An unset field decodes to "".
+func (c *Codec) Decode(f api.CustomField) string {
+	if f.Value == nil {
+		return ""
+	}
+	var v interface{}
+	data, err := json.Marshal(f.Value)
+	if err != nil {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if dec.Decode(&v) != nil || v == nil {
+		return ""
+	}
+	if fc, ok := codecs[f.Type]; ok {
+		return fc.decode(TypeConfig(f), v)
+	}
+	if s, ok := scalar(v); ok {
+		return s
+	}
+	return string(data)
+}
+
+func encodeText(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	return s, nil, nil
+}
+
+func encodeURL(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, nil, invalid("expected an absolute URL such as https://example.com")
+	}
+	return s, nil, nil
+}
+
+func encodeEmail(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return nil, nil, invalid("expected an email address such as ada@example.com")
+	}
+	return s, nil, nil
+}
+
+func encodePhone(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	digits := 0
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case strings.ContainsRune(" -().", r):
+		default:
+			digits = -1
+		}
+		if digits < 0 {
+			break
+		}
+	}
+	if s[0] != '+' || digits < 7 || digits > 15 {
+		return nil, nil, invalid("expected a phone number with its country code, such as +1 201 555 0123")
+	}
+	return s, nil, nil
+}
+
+func encodeCheckbox(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	switch strings.ToLower(s) {
+	case "true", "yes", "y", "on", "1", "checked":
+		return true, nil, nil
+	case "false", "no", "n", "off", "0", "unchecked":
+		return false, nil, nil
+	}
+	return nil, nil, invalid("expected true or false")
+}
+
+func encodeNumber(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	n, err := parseNumber(s)
+	if err != nil {
+		return nil, nil, invalid("expected a number")
+	}
+	return n, nil, nil
+}
+
+func encodeCurrency(_ *Codec, cfg Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	n, err := parseNumber(s)
+	if err != nil {
+		return nil, nil, invalid("expected an amount such as 12.50")
+	}
+	if cfg.Precision != nil && *cfg.Precision >= 0 {
+		// Any form of the amount, such as 1e-5, must survive rounding to
+		// the precision.
+		rounded, _ := strconv.ParseFloat(strconv.FormatFloat(n, 'f', *cfg.Precision, 64), 64)
+		if rounded != n {
+			return nil, nil, invalid("at most %d decimal places", *cfg.Precision)
+		}
+	}
+	return n, nil, nil
+}
+
+func encodeEmoji(_ *Codec, cfg Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || (cfg.Count > 0 && n > cfg.Count) {
+		if cfg.Count > 0 {
+			return nil, nil, invalid("expected a rating from 0 to %d", cfg.Count)
+		}
+		return nil, nil, invalid("expected a rating of 0 or more")
+	}
+	return n, nil, nil
+}
+
+func encodeDate(c *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	parse := c.ParseDate
+	if parse == nil {
+		parse = parseDate
+	}
+	t, err := parse(s)
+	if err != nil {
+		return nil, nil, invalid("%v", err)
+	}
+	var opts *api.CustomFieldValueOptions
+	if h, m, sec := t.Clock(); h != 0 || m != 0 || sec != 0 || t.Nanosecond() != 0 {
+		opts = &api.CustomFieldValueOptions{Time: true}
+	}
+	return t.UnixMilli(), opts, nil
+}
+
+func encodeDropDown(_ *Codec, cfg Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	o, err := findOption(cfg.Options, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.ID, nil, nil
+}
+
+func encodeLabels(_ *Codec, cfg Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	ids := []string{}
+	for _, ref := range splitList(s) {
+		o, err := findOption(cfg.Options, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, o.ID)
+	}
+	return ids, nil, nil
+}
+
+func encodeUsers(c *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	change := Change[int]{Add: []int{}, Rem: []int{}}
+	for _, ref := range splitList(s) {
+		rem := strings.HasPrefix(ref, "-")
+		ref = strings.TrimPrefix(ref, "-")
+		var id int
+		var err error
+		if c.ResolveUser != nil {
+			id, err = c.ResolveUser(ref)
+		} else if id, err = strconv.Atoi(ref); err != nil {
+			err = invalid("expected user IDs, got %q", ref)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if rem {
+			change.Rem = append(change.Rem, id)
+		} else {
+			change.Add = append(change.Add, id)
+		}
+	}
+	return change, nil, nil
+}
+
+func encodeTasks(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	change := Change[string]{Add: []string{}, Rem: []string{}}
+	for _, ref := range splitList(s) {
+		if id, ok := strings.CutPrefix(ref, "-"); ok {
+			change.Rem = append(change.Rem, id)
+		} else {
+			change.Add = append(change.Add, ref)
+		}
+	}
+	return change, nil, nil
+}
+
+func encodeProgress(_ *Codec, cfg Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	n, err := parseNumber(strings.TrimSuffix(s, "%"))
+	if err != nil {
+		n = math.NaN()
+	}
+	return checkProgress(cfg, n)
+}
+
+// checkProgress returns n as a progress value if it is within the field's
+// start and end, 0 to 100 by default.
+func checkProgress(cfg Config, n float64) (interface{}, *api.CustomFieldValueOptions, error) {
+	start, end := 0.0, 100.0
+	if cfg.Start != nil {
+		start = float64(*cfg.Start)
+	}
+	if cfg.End != nil {
+		end = float64(*cfg.End)
+	}
+	if math.IsNaN(n) || n < start || n > end {
+		return nil, nil, invalid("expected a number from %g to %g", start, end)
+	}
+	return Progress{Current: n}, nil, nil
+}
+
+func encodeLocation(_ *Codec, _ Config, s string) (interface{}, *api.CustomFieldValueOptions, error) {
+	parts := strings.SplitN(s, ",", 3)
+	if len(parts) < 2 {
+		return nil, nil, invalid("expected LAT,LNG or LAT,LNG,ADDRESS")
+	}
+	lat, err1 := parseNumber(strings.TrimSpace(parts[0]))
+	lng, err2 := parseNumber(strings.TrimSpace(parts[1]))
+	if err1 != nil || err2 != nil || !validLatLng(LatLng{Lat: lat, Lng: lng}) {
+		return nil, nil, invalid("expected a latitude from -90 to 90 and a longitude from -180 to 180")
+	}
+	loc := Location{Location: LatLng{Lat: lat, Lng: lng}}
+	if len(parts) == 3 {
+		loc.FormattedAddress = strings.TrimSpace(parts[2])
+	}
+	return loc, nil, nil
+}
+
+func validLatLng(p LatLng) bool {
+	return math.Abs(p.Lat) <= 90 && math.Abs(p.Lng) <= 180
+}
+
+// decodeStrict decodes a JSON object given as a field value into v,
+// rejecting keys v does not have.
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return invalid("invalid JSON object: %v", err)
+	}
+	return nil
+}
+
+func objectChange[T any](_ Config, data []byte) (interface{}, error) {
+	var change Change[T]
+	if err := decodeStrict(data, &change); err != nil {
+		return nil, err
+	}
+	if len(change.Add) == 0 && len(change.Rem) == 0 {
+		return nil, invalid(`expected {"add": [...], "rem": [...]} with at least one ID`)
+	}
+	if change.Add == nil {
+		change.Add = []T{}
+	}
+	if change.Rem == nil {
+		change.Rem = []T{}
+	}
+	return change, nil
+}
+
+func objectProgress(cfg Config, data []byte) (interface{}, error) {
+	var p struct {
+		Current *float64 `json:"current"`
+	}
+	if err := decodeStrict(data, &p); err != nil {
+		return nil, err
+	}
+	if p.Current == nil {
+		return nil, invalid(`expected {"current": N}`)
+	}
+	v, _, err := checkProgress(cfg, *p.Current)
+	return v, err
+}
+
+func objectLocation(_ Config, data []byte) (interface{}, error) {
+	var loc struct {
+		Location         *LatLng `json:"location"`
+		FormattedAddress string  `json:"formatted_address"`
+	}
+	if err := decodeStrict(data, &loc); err != nil {
+		return nil, err
+	}
+	if loc.Location == nil || !validLatLng(*loc.Location) {
+		return nil, invalid(`expected {"location": {"lat": LAT, "lng": LNG}} with a latitude from -90 to 90 and a longitude from -180 to 180`)
+	}
+	return Location{Location: *loc.Location, FormattedAddress: loc.FormattedAddress}, nil
+}
+
+// findOption matches ref against option IDs, then option names in any case.
+func findOption(options []Option, ref string) (Option, error) {
+	var matches []Option
+	for _, o := range options {
+		if o.ID == ref {
+			return o, nil
+		}
+		if strings.EqualFold(o.title(), ref) {
+			matches = append(matches, o)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	choices := make([]string, 0, len(options))
+	for _, o := range options {
+		choices = append(choices, o.title())
+	}
+	e := invalid("no option %q", ref)
+	if len(matches) > 1 {
+		e = invalid("option %q is ambiguous; use its ID", ref)
+	}
+	e.Choices = choices
+	return Option{}, e
+}
+
+// splitList reads a comma-separated list, or a JSON array.
+func splitList(s string) []string {
+	var refs []string
+	var arr []interface{}
+	if strings.HasPrefix(s, "[") && json.Unmarshal([]byte(s), &arr) == nil {
+		for _, v := range arr {
+			if ref, ok := scalar(v); ok {
+				refs = append(refs, ref)
+			}
+		}
+		return refs
+	}
+	for _, ref := range strings.Split(s, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func parseNumber(s string) (float64, error) {
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, errors.New("not a number")
+	}
+	return n, nil
+}
+
+// parseDate is the default Codec.ParseDate.
+func parseDate(s string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD, RFC 3339 or Unix milliseconds", s)
+}
+
+// scalar formats a decoded JSON string, number or boolean.
+func scalar(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case json.Number:
+		return x.String(), true
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(x), true
+	}
+	return "", false
+}
+
+func decodeScalar(_ Config, v interface{}) string {
+	if s, ok := scalar(v); ok {
+		return s
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func decodeDate(_ Config, v interface{}) string {
+	s, _ := scalar(v)
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return s
+	}
+	t := time.UnixMilli(ms).In(time.Local)
+	if h, m, sec := t.Clock(); h == 0 && m == 0 && sec == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// decodeDropDown names the option whose ID or orderindex is v; ClickUp
+// returns the orderindex for tasks.
+func decodeDropDown(cfg Config, v interface{}) string {
+	s, _ := scalar(v)
+	for _, o := range cfg.Options {
+		if o.ID == s || strconv.FormatInt(int64(o.OrderIndex), 10) == s {
+			return o.title()
+		}
+	}
+	return s
+}
+
+func decodeLabels(cfg Config, v interface{}) string {
+	ids, _ := v.([]interface{})
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s, _ := scalar(id)
+		name := s
+		for _, o := range cfg.Options {
+			if o.ID == s {
+				name = o.title()
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+// decodeIDs lists the IDs of the users or tasks in a relationship field.
+func decodeIDs(_ Config, v interface{}) string {
+	items, _ := v.([]interface{})
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			item = m["id"]
+		}
+		if s, ok := scalar(item); ok {
+			ids = append(ids, s)
+		}
+	}
+	return strings.Join(ids, ",")
+}
+
+func decodeProgress(_ Config, v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		v = m["current"]
+	}
+	s, _ := scalar(v)
+	return s
+}
+
+func decodeLocation(_ Config, v interface{}) string {
+	m, _ := v.(map[string]interface{})
+	loc, _ := m["location"].(map[string]interface{})
+	lat, _ := scalar(loc["lat"])
+	lng, _ := scalar(loc["lng"])
+	if lat == "" || lng == "" {
+		return ""
+	}
+	s := lat + "," + lng
+	if addr, _ := m["formatted_address"].(string); addr != "" {
+		s += "," + addr
+	}
+	return s
+}
+
+// Find returns the field in fields whose ID is ref, or else whose name is
+// ref in any case. It fails with a *resolve.Error listing the fields.
+func Find(fields []api.CustomField, ref string) (api.CustomField, error) {
+	var matches []api.CustomField
+	for _, f := range fields {
+		if f.ID == ref {
+			return f, nil
+		}
+		if strings.EqualFold(f.Name, ref) {
+			matches = append(matches, f)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	e := &resolve.Error{Kind: "custom field", Ref: ref, Ambiguous: len(matches) > 1}
+	if !e.Ambiguous {
+		matches = fields
+	}
+	for _, f := range matches {
+		e.Candidates = append(e.Candidates, resolve.Candidate{ID: f.ID, Name: f.Name})
+	}
+	return api.CustomField{}, e
+}
@@ -0,0 +1,230 @@
+package customfield
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/resolve"
+)
+
+var (
+	dropDown = api.CustomField{ID: "f1", Name: "Priority", Type: TypeDropDown, TypeConfig: map[string]interface{}{
+		"options": []interface{}{
+			map[string]interface{}{"id": "opt-high", "name": "High", "orderindex": 0.0},
+			map[string]interface{}{"id": "opt-low", "name": "Low", "orderindex": 1.0},
+		},
+	}}
+	labels = api.CustomField{ID: "f2", Name: "Areas", Type: TypeLabels, TypeConfig: map[string]interface{}{
+		"options": []interface{}{
+			map[string]interface{}{"id": "l1", "label": "API"},
+			map[string]interface{}{"id": "l2", "label": "UI"},
+		},
+	}}
+	currency = api.CustomField{Name: "Budget", Type: TypeCurrency, TypeConfig: map[string]interface{}{"precision": 2.0, "currency_type": "USD"}}
+	rating   = api.CustomField{Name: "Rating", Type: TypeEmoji, TypeConfig: map[string]interface{}{"count": 5.0, "code_point": "2b50"}}
+	progress = api.CustomField{Name: "Done", Type: TypeManualProgress, TypeConfig: map[string]interface{}{"start": 0.0, "end": 10.0}}
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		field api.CustomField
+		value string
+		want  string
+		time  bool
+	}{
+		{name: "dropdown name", field: dropDown, value: "high", want: `"opt-high"`},
+		{name: "dropdown ID", field: dropDown, value: "opt-low", want: `"opt-low"`},
+		{name: "labels", field: labels, value: "ui, API", want: `["l2","l1"]`},
+		{name: "labels JSON array", field: labels, value: `["l1"]`, want: `["l1"]`},
+		{name: "currency", field: currency, value: "12.50", want: `12.5`},
+		{name: "rating", field: rating, value: "4", want: `4`},
+		{name: "progress", field: progress, value: "7", want: `{"current":7}`},
+		{name: "number", field: api.CustomField{Type: TypeNumber}, value: "-3.25", want: `-3.25`},
+		{name: "checkbox", field: api.CustomField{Type: TypeCheckbox}, value: "yes", want: `true`},
+		{name: "text", field: api.CustomField{Type: TypeShortText}, value: "Sprint 43", want: `"Sprint 43"`},
+		{name: "quoted text", field: api.CustomField{Type: TypeText}, value: `"Sprint 43"`, want: `"Sprint 43"`},
+		{name: "url", field: api.CustomField{Type: TypeURL}, value: "https://example.com/a", want: `"https://example.com/a"`},
+		{name: "email", field: api.CustomField{Type: TypeEmail}, value: "ada@example.com", want: `"ada@example.com"`},
+		{name: "phone", field: api.CustomField{Type: TypePhone}, value: "+1 201 555 0123", want: `"+1 201 555 0123"`},
+		{name: "users", field: api.CustomField{Type: TypeUsers}, value: "183,-184", want: `{"add":[183],"rem":[184]}`},
+		{name: "tasks", field: api.CustomField{Type: TypeTasks}, value: "abc, -def", want: `{"add":["abc"],"rem":["def"]}`},
+		{name: "location", field: api.CustomField{Type: TypeLocation}, value: "52.52,13.405,Berlin, Germany", want: `{"location":{"lat":52.52,"lng":13.405},"formatted_address":"Berlin, Germany"}`},
+		{name: "users JSON object", field: api.CustomField{Type: TypeUsers}, value: `{"add":[1]}`, want: `{"add":[1],"rem":[]}`},
+		{name: "tasks JSON object", field: api.CustomField{Type: TypeListRelationship}, value: `{"rem":["abc"]}`, want: `{"add":[],"rem":["abc"]}`},
+		{name: "progress JSON object", field: progress, value: `{"current":3}`, want: `{"current":3}`},
+		{name: "location JSON object", field: api.CustomField{Type: TypeLocation}, value: `{"location":{"lat":1,"lng":2}}`, want: `{"location":{"lat":1,"lng":2},"formatted_address":""}`},
+		{name: "text with braces", field: api.CustomField{Type: TypeText}, value: `{"a":1}`, want: `"{\"a\":1}"`},
+		{name: "currency exponent", field: currency, value: "1.5e1", want: `15`},
+		{name: "unknown type JSON", field: api.CustomField{Type: "votes"}, value: `[1,2]`, want: `[1,2]`},
+		{name: "unknown type string", field: api.CustomField{Type: "votes"}, value: `up`, want: `"up"`},
+	}
+	var c Codec
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := c.Encode(tt.field, tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := json.Marshal(req.Value)
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			if (req.ValueOptions != nil && req.ValueOptions.Time) != tt.time {
+				t.Errorf("got value options %+v", req.ValueOptions)
+			}
+		})
+	}
+}
+
+func TestEncodeDate(t *testing.T) {
+	var c Codec
+	req, err := c.Encode(api.CustomField{Type: TypeDate}, "2025-01-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 1, 31, 0, 0, 0, 0, time.Local).UnixMilli(); req.Value != want || req.ValueOptions != nil {
+		t.Errorf("got %v %+v, want %d without a time", req.Value, req.ValueOptions, want)
+	}
+	req, err = c.Encode(api.CustomField{Type: TypeDate}, "2025-01-31T09:30:00Z")
+	if err != nil || req.ValueOptions == nil || !req.ValueOptions.Time {
+		t.Errorf("expected a date with a time, got %+v, %v", req, err)
+	}
+
+	c.ParseDate = func(s string) (time.Time, error) { return time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC), nil }
+	if req, err := c.Encode(api.CustomField{Type: TypeDate}, "next friday"); err != nil || req.Value != int64(1772755200000) {
+		t.Errorf("expected the custom parser to be used, got %+v, %v", req, err)
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   api.CustomField
+		value   string
+		reason  string
+		choices bool
+	}{
+		{name: "unknown option", field: dropDown, value: "Urgent", reason: `no option "Urgent"`, choices: true},
+		{name: "unknown label", field: labels, value: "API,Docs", reason: `no option "Docs"`, choices: true},
+		{name: "currency precision", field: currency, value: "1.005", reason: "at most 2 decimal places"},
+		{name: "currency exponent precision", field: currency, value: "1e-5", reason: "at most 2 decimal places"},
+		{name: "dropdown JSON object", field: dropDown, value: `{"id":"opt-high"}`, reason: "no option", choices: true},
+		{name: "number JSON object", field: api.CustomField{Type: TypeNumber}, value: `{"value":1}`, reason: "expected a number"},
+		{name: "users JSON object", field: api.CustomField{Type: TypeUsers}, value: `{"add":["alice"]}`, reason: "invalid JSON object"},
+		{name: "users JSON object key", field: api.CustomField{Type: TypeUsers}, value: `{"added":[1]}`, reason: "invalid JSON object"},
+		{name: "progress JSON object", field: progress, value: `{"current":11}`, reason: "from 0 to 10"},
+		{name: "location JSON object", field: api.CustomField{Type: TypeLocation}, value: `{"location":{"lat":91,"lng":0}}`, reason: "latitude"},
+		{name: "rating range", field: rating, value: "6", reason: "from 0 to 5"},
+		{name: "progress range", field: progress, value: "11", reason: "from 0 to 10"},
+		{name: "number", field: api.CustomField{Type: TypeNumber}, value: "lots", reason: "expected a number"},
+		{name: "checkbox", field: api.CustomField{Type: TypeCheckbox}, value: "maybe", reason: "true or false"},
+		{name: "url", field: api.CustomField{Type: TypeURL}, value: "example.com", reason: "absolute URL"},
+		{name: "email", field: api.CustomField{Type: TypeEmail}, value: "Ada <ada@example.com>", reason: "email address"},
+		{name: "phone", field: api.CustomField{Type: TypePhone}, value: "555 0123", reason: "country code"},
+		{name: "date", field: api.CustomField{Type: TypeDate}, value: "someday", reason: "invalid date"},
+		{name: "users", field: api.CustomField{Type: TypeUsers}, value: "alice", reason: "expected user IDs"},
+		{name: "location", field: api.CustomField{Type: TypeLocation}, value: "91,0", reason: "latitude"},
+		{name: "formula", field: api.CustomField{Type: TypeFormula}, value: "1", reason: "cannot be set"},
+		{name: "empty", field: api.CustomField{Type: TypeText}, value: " ", reason: "empty value"},
+	}
+	var c Codec
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.field.Name = "Field"
+			_, err := c.Encode(tt.field, tt.value)
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("expected an *Error, got %v", err)
+			}
+			if !strings.Contains(e.Reason, tt.reason) || e.Field != "Field" || e.Value != tt.value {
+				t.Errorf("got %+v", e)
+			}
+			if (len(e.Choices) > 0) != tt.choices {
+				t.Errorf("got choices %v", e.Choices)
+			}
+		})
+	}
+}
+
+func TestEncodeResolveUser(t *testing.T) {
+	notFound := errors.New("no user named bob")
+	c := Codec{ResolveUser: func(ref string) (int, error) {
+		if ref == "alice" {
+			return 183, nil
+		}
+		return 0, notFound
+	}}
+	req, err := c.Encode(api.CustomField{Type: TypeUsers}, "alice")
+	if got, _ := json.Marshal(req.Value); err != nil || string(got) != `{"add":[183],"rem":[]}` {
+		t.Errorf("got %s, %v", got, err)
+	}
+	if _, err := c.Encode(api.CustomField{Type: TypeUsers}, "alice,-bob"); err != notFound {
+		t.Errorf("expected the resolver's error, got %v", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	withValue := func(f api.CustomField, v interface{}) api.CustomField {
+		f.Value = v
+		return f
+	}
+	midnight := time.Date(2025, 1, 31, 0, 0, 0, 0, time.Local)
+	noon := midnight.Add(12 * time.Hour)
+	tests := []struct {
+		field api.CustomField
+		want  string
+	}{
+		{withValue(dropDown, 1.0), "Low"},
+		{withValue(dropDown, "opt-high"), "High"},
+		{withValue(labels, []interface{}{"l2", "l9"}), "UI,l9"},
+		{withValue(api.CustomField{Type: TypeDate}, millisString(midnight)), "2025-01-31"},
+		{withValue(api.CustomField{Type: TypeDate}, millisString(noon)), noon.Format(time.RFC3339)},
+		{withValue(api.CustomField{Type: TypeUsers}, []interface{}{map[string]interface{}{"id": 183.0, "username": "alice"}}), "183"},
+		{withValue(api.CustomField{Type: TypeTasks}, []interface{}{map[string]interface{}{"id": "abc"}, map[string]interface{}{"id": "def"}}), "abc,def"},
+		{withValue(progress, map[string]interface{}{"current": "7", "percent_completed": 0.7}), "7"},
+		{withValue(api.CustomField{Type: TypeLocation}, map[string]interface{}{"location": map[string]interface{}{"lat": 52.52, "lng": 13.405}, "formatted_address": "Berlin"}), "52.52,13.405,Berlin"},
+		{withValue(api.CustomField{Type: TypeCheckbox}, true), "true"},
+		{withValue(currency, 12.5), "12.5"},
+		{api.CustomField{Type: TypeText}, ""},
+	}
+	var c Codec
+	for _, tt := range tests {
+		if got := c.Decode(tt.field); got != tt.want {
+			t.Errorf("%s %v: got %q, want %q", tt.field.Type, tt.field.Value, got, tt.want)
+		}
+	}
+
+	// What Decode returns, Encode accepts.
+	f := withValue(labels, []interface{}{"l1", "l2"})
+	req, err := c.Encode(f, c.Decode(f))
+	if got, _ := json.Marshal(req.Value); err != nil || string(got) != `["l1","l2"]` {
+		t.Errorf("round trip: got %s, %v", got, err)
+	}
+}
+
+func millisString(t time.Time) string {
+	data, _ := json.Marshal(t.UnixMilli())
+	return string(data)
+}
+
+func TestFind(t *testing.T) {
+	fields := []api.CustomField{dropDown, labels, {ID: "f3", Name: "areas"}}
+	if f, err := Find(fields, "priority"); err != nil || f.ID != "f1" {
+		t.Errorf("expected a match by name, got %+v, %v", f, err)
+	}
+	if f, err := Find(fields, "f3"); err != nil || f.Name != "areas" {
+		t.Errorf("expected a match by ID, got %+v, %v", f, err)
+	}
+	var re *resolve.Error
+	if _, err := Find(fields, "Areas"); !errors.As(err, &re) || !re.Ambiguous || len(re.Candidates) != 2 {
+		t.Errorf("expected an ambiguous name, got %v", err)
+	}
+	if _, err := Find(fields, "Sprint"); !errors.As(err, &re) || re.Ambiguous || len(re.Candidates) != 3 {
+		t.Errorf("expected the fields as candidates, got %v", err)
+	}
+}
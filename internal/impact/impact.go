@@ -0,0 +1,293 @@
+// Package impact works out what deleting a space, folder or list would
+// remove along with it.
+package impact
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// Target types.
+const (
+	TypeSpace  = "space"
+	TypeFolder = "folder"
+	TypeList   = "list"
+)
+
+// DefaultConcurrency is how many requests Walk makes at once by default.
+const DefaultConcurrency = 8
+
+// docParentTypes are the doc parent types ClickUp uses for each target type.
+var docParentTypes = map[string]int{TypeSpace: 4, TypeFolder: 5, TypeList: 6}
+
+// IDs is a count of removed objects and their IDs.
+type IDs struct {
+	Count int      `json:"count"`
+	IDs   []string `json:"ids"`
+}
+
+func (s *IDs) add(id string) {
+	s.Count++
+	s.IDs = append(s.IDs, id)
+}
+
+// Report is everything a delete would remove. Folders and Lists include the
+// archived ones. Tasks are top-level tasks and Subtasks the tasks with a
+// parent, open, closed or archived alike; Views are
+// the views on the target and the folders and lists in it, and Docs the docs
+// they hold. TimeSpentMs is the time tracked on all of those tasks.
+type Report struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Folders     IDs    `json:"folders"`
+	Lists       IDs    `json:"lists"`
+	Tasks       IDs    `json:"tasks"`
+	Subtasks    IDs    `json:"subtasks"`
+	Views       IDs    `json:"views"`
+	Docs        IDs    `json:"docs"`
+	TimeSpentMs int64  `json:"time_spent_ms"`
+}
+
+// TaskCount is the number of tasks and subtasks the delete would remove.
+func (r *Report) TaskCount() int {
+	return r.Tasks.Count + r.Subtasks.Count
+}
+
+// Walk reports what deleting the space, folder or list id would remove.
+// Docs are found among the docs of workspaceID. Tasks and views are fetched
+// concurrently; the first error stops the walk.
+func Walk(ctx context.Context, client api.ClientInterface, workspaceID, typ, id string) (*Report, error) {
+	if _, ok := docParentTypes[typ]; !ok {
+		return nil, fmt.Errorf("unknown target type %q", typ)
+	}
+	r := &Report{Type: typ, ID: id}
+	for _, ids := range []*IDs{&r.Folders, &r.Lists, &r.Tasks, &r.Subtasks, &r.Views, &r.Docs} {
+		ids.IDs = []string{}
+	}
+
+	// containers are the target and the folders and lists in it, in walk
+	// order.
+	type container struct{ typ, id string }
+	containers := []container{{typ, id}}
+	var lists []string
+	switch typ {
+	case TypeSpace:
+		folders, err := spaceFolders(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range folders {
+			r.Folders.add(f)
+			containers = append(containers, container{TypeFolder, f})
+			ids, err := folderLists(ctx, client, f)
+			if err != nil {
+				return nil, err
+			}
+			lists = append(lists, ids...)
+		}
+		ids, err := collectLists(func(opts api.ListListsOptions) (*api.ListsResponse, error) {
+			return client.ListFolderlessLists(ctx, id, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, ids...)
+	case TypeFolder:
+		var err error
+		if lists, err = folderLists(ctx, client, id); err != nil {
+			return nil, err
+		}
+	case TypeList:
+		lists = []string{id}
+	}
+	if typ != TypeList {
+		for _, l := range lists {
+			r.Lists.add(l)
+			containers = append(containers, container{TypeList, l})
+		}
+	}
+
+	tasks := make([][]api.Task, len(lists))
+	views := make([][]api.View, len(containers))
+	var docs []api.Doc
+	err := each(ctx, len(lists)+len(containers)+1, DefaultConcurrency, func(ctx context.Context, i int) error {
+		var err error
+		switch {
+		case i < len(lists):
+			tasks[i], err = listTasks(ctx, client, lists[i])
+		case i < len(lists)+len(containers):
+			c := containers[i-len(lists)]
+			views[i-len(lists)], err = containerViews(ctx, client, c.typ, c.id)
+		default:
+			docs, err = searchDocs(ctx, client, workspaceID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, page := range tasks {
+		for _, t := range page {
+			if seen[t.ID] {
+				continue
+			}
+			seen[t.ID] = true
+			if t.Parent != nil && *t.Parent != "" {
+				r.Subtasks.add(t.ID)
+			} else {
+				r.Tasks.add(t.ID)
+			}
+			if t.TimeSpent != nil {
+				r.TimeSpentMs += int64(*t.TimeSpent)
+			}
+		}
+	}
+	for _, vs := range views {
+		for _, v := range vs {
+			r.Views.add(v.ID)
+		}
+	}
+	removed := map[api.DocParent]bool{}
+	for _, c := range containers {
+		removed[api.DocParent{ID: c.id, Type: docParentTypes[c.typ]}] = true
+	}
+	for _, d := range docs {
+		if !d.Deleted && d.Parent != nil && removed[*d.Parent] {
+			r.Docs.add(d.ID)
+		}
+	}
+	return r, nil
+}
+
+// spaceFolders returns the IDs of a space's folders, active then archived:
+// deleting the space removes both.
+func spaceFolders(ctx context.Context, client api.ClientInterface, spaceID string) ([]string, error) {
+	var ids []string
+	for _, archived := range []bool{false, true} {
+		resp, err := client.ListFolders(ctx, spaceID, api.ListFoldersOptions{Archived: archived})
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range resp.Folders {
+			ids = append(ids, f.ID)
+		}
+	}
+	return ids, nil
+}
+
+func folderLists(ctx context.Context, client api.ClientInterface, folderID string) ([]string, error) {
+	return collectLists(func(opts api.ListListsOptions) (*api.ListsResponse, error) {
+		return client.ListLists(ctx, folderID, opts)
+	})
+}
+
+// collectLists returns the IDs of the lists fetch finds, active then
+// archived.
+func collectLists(fetch func(api.ListListsOptions) (*api.ListsResponse, error)) ([]string, error) {
+	var ids []string
+	for _, archived := range []bool{false, true} {
+		resp, err := fetch(api.ListListsOptions{Archived: archived})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range resp.Lists {
+			ids = append(ids, l.ID)
+		}
+	}
+	return ids, nil
+}
+
+// listTasks fetches every task in a list, subtasks and closed tasks
+// included, then the archived ones, which ClickUp lists separately.
+func listTasks(ctx context.Context, client api.ClientInterface, listID string) ([]api.Task, error) {
+	var all []api.Task
+	for _, archived := range []bool{false, true} {
+		fetch := func(ctx context.Context, page int) ([]api.Task, bool, error) {
+			resp, err := client.ListTasks(ctx, listID, &api.ListTasksOptions{Page: page, Subtasks: true, IncludeClosed: true, Archived: archived})
+			if err != nil {
+				return nil, false, err
+			}
+			return resp.Tasks, resp.LastPage, nil
+		}
+		tasks, _, err := api.CollectPages(ctx, fetch, api.PaginateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tasks...)
+	}
+	return all, nil
+}
+
+// searchDocs returns every doc of workspaceID, following the cursor from
+// page to page.
+func searchDocs(ctx context.Context, client api.ClientInterface, workspaceID string) ([]api.Doc, error) {
+	var all []api.Doc
+	var opts api.SearchDocsOptions
+	for {
+		resp, err := client.SearchDocs(ctx, workspaceID, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Docs...)
+		if resp.NextCursor == "" || resp.NextCursor == opts.Cursor || len(resp.Docs) == 0 {
+			return all, nil
+		}
+		opts.Cursor = resp.NextCursor
+	}
+}
+
+func containerViews(ctx context.Context, client api.ClientInterface, typ, id string) ([]api.View, error) {
+	var resp *api.ViewsResponse
+	var err error
+	switch typ {
+	case TypeSpace:
+		resp, err = client.GetSpaceViews(ctx, id)
+	case TypeFolder:
+		resp, err = client.GetFolderViews(ctx, id)
+	default:
+		resp, err = client.GetListViews(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Views, nil
+}
+
+// each calls fn for 0 to n-1, at most concurrency at a time, and returns the
+// first error, which cancels the calls not yet started.
+func each(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var first error
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if first == nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return first
+}
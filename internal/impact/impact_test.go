@@ -0,0 +1,140 @@
+package impact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/testutil"
+)
+
+func space() (*testutil.MockClient, *[]api.ListTasksOptions) {
+	var mu sync.Mutex
+	var calls []api.ListTasksOptions
+	archivedMs, openMs, parent := api.FlexInt(500), api.FlexInt(1000), "t1"
+	views := func(id string) (*api.ViewsResponse, error) {
+		return &api.ViewsResponse{Views: []api.View{{ID: "v" + id}}}, nil
+	}
+	return &testutil.MockClient{
+		ListFoldersFn: func(_ context.Context, _ string, opts ...api.ListFoldersOptions) (*api.FoldersResponse, error) {
+			if opts[0].Archived {
+				return &api.FoldersResponse{Folders: []api.Folder{{ID: "11"}}}, nil
+			}
+			return &api.FoldersResponse{Folders: []api.Folder{{ID: "10"}}}, nil
+		},
+		ListListsFn: func(_ context.Context, folderID string, opts ...api.ListListsOptions) (*api.ListsResponse, error) {
+			switch {
+			case folderID != "10":
+				return &api.ListsResponse{}, nil
+			case opts[0].Archived:
+				return &api.ListsResponse{Lists: []api.List{{ID: "101"}}}, nil
+			}
+			return &api.ListsResponse{Lists: []api.List{{ID: "100"}}}, nil
+		},
+		ListFolderlessListsFn: func(_ context.Context, _ string, opts ...api.ListListsOptions) (*api.ListsResponse, error) {
+			if opts[0].Archived {
+				return &api.ListsResponse{}, nil
+			}
+			return &api.ListsResponse{Lists: []api.List{{ID: "120"}}}, nil
+		},
+		ListTasksFn: func(_ context.Context, listID string, opts *api.ListTasksOptions) (*api.TasksResponse, error) {
+			mu.Lock()
+			calls = append(calls, *opts)
+			mu.Unlock()
+			switch {
+			case listID == "100" && opts.Archived:
+				return &api.TasksResponse{Tasks: []api.Task{{ID: "a1", TimeSpent: &archivedMs}}, LastPage: true}, nil
+			case listID == "100" && opts.Page == 0:
+				return &api.TasksResponse{Tasks: []api.Task{{ID: "t1", TimeSpent: &openMs}, {ID: "s1", Parent: &parent}}}, nil
+			case listID == "100":
+				// The same task may come back on a later page.
+				return &api.TasksResponse{Tasks: []api.Task{{ID: "t1"}, {ID: "t2"}}, LastPage: true}, nil
+			case listID == "101" && !opts.Archived:
+				// An archived list's tasks go with it.
+				return &api.TasksResponse{Tasks: []api.Task{{ID: "t4"}}, LastPage: true}, nil
+			case listID == "120" && !opts.Archived:
+				return &api.TasksResponse{Tasks: []api.Task{{ID: "t3"}}, LastPage: true}, nil
+			}
+			return &api.TasksResponse{LastPage: true}, nil
+		},
+		GetSpaceViewsFn:  func(_ context.Context, id string) (*api.ViewsResponse, error) { return views(id) },
+		GetFolderViewsFn: func(_ context.Context, id string) (*api.ViewsResponse, error) { return views(id) },
+		GetListViewsFn:   func(_ context.Context, id string) (*api.ViewsResponse, error) { return views(id) },
+		SearchDocsFn: func(_ context.Context, _ string, opts ...api.SearchDocsOptions) (*api.DocsResponse, error) {
+			if len(opts) > 0 && opts[0].Cursor == "page2" {
+				return &api.DocsResponse{Docs: []api.Doc{
+					{ID: "d4", Parent: &api.DocParent{ID: "9", Type: 4}},
+				}, NextCursor: "page2"}, nil
+			}
+			return &api.DocsResponse{Docs: []api.Doc{
+				{ID: "d1", Parent: &api.DocParent{ID: "10", Type: 5}},
+				{ID: "d2", Parent: &api.DocParent{ID: "10", Type: 6}},
+				{ID: "d3", Parent: &api.DocParent{ID: "120", Type: 6}, Deleted: true},
+			}, NextCursor: "page2"}, nil
+		},
+	}, &calls
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWalkSpace(t *testing.T) {
+	mock, calls := space()
+	r, err := Walk(context.Background(), mock, "1", TypeSpace, "9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `{"type":"space","id":"9",
+		"folders":{"count":2,"ids":["10","11"]},
+		"lists":{"count":3,"ids":["100","101","120"]},
+		"tasks":{"count":5,"ids":["t1","t2","a1","t4","t3"]},
+		"subtasks":{"count":1,"ids":["s1"]},
+		"views":{"count":6,"ids":["v9","v10","v11","v100","v101","v120"]},
+		"docs":{"count":2,"ids":["d1","d4"]},
+		"time_spent_ms":1500}`, mustJSON(t, r))
+	if r.TaskCount() != 6 {
+		t.Errorf("expected 6 tasks and subtasks, got %d", r.TaskCount())
+	}
+	for _, opts := range *calls {
+		if !opts.Subtasks || !opts.IncludeClosed {
+			t.Errorf("expected subtasks and closed tasks to be listed, got %+v", opts)
+		}
+	}
+}
+
+func TestWalkList(t *testing.T) {
+	mock, _ := space()
+	r, err := Walk(context.Background(), mock, "1", TypeList, "120")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `{"type":"list","id":"120",
+		"folders":{"count":0,"ids":[]},
+		"lists":{"count":0,"ids":[]},
+		"tasks":{"count":1,"ids":["t3"]},
+		"subtasks":{"count":0,"ids":[]},
+		"views":{"count":1,"ids":["v120"]},
+		"docs":{"count":0,"ids":[]},
+		"time_spent_ms":0}`, mustJSON(t, r))
+}
+
+func TestWalkError(t *testing.T) {
+	mock, _ := space()
+	boom := errors.New("boom")
+	mock.GetListViewsFn = func(context.Context, string) (*api.ViewsResponse, error) { return nil, boom }
+	if _, err := Walk(context.Background(), mock, "1", TypeFolder, "10"); !errors.Is(err, boom) {
+		t.Errorf("expected the failing call's error, got %v", err)
+	}
+	if _, err := Walk(context.Background(), mock, "1", "task", "t1"); err == nil {
+		t.Error("expected an error for an unknown target type")
+	}
+}
@@ -0,0 +1,354 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// columns restricts and orders the columns written by the csv and tsv formats.
+var columns []string
+
+// SetColumns selects the columns written by the csv and tsv formats, in
+// order. An empty list writes every column.
+func SetColumns(names []string) {
+	columns = nil
+	for _, n := range names {
+		if n = strings.TrimSpace(n); n != "" {
+			columns = append(columns, n)
+		}
+	}
+}
+
+// delimitedRenderer writes results as CSV or TSV with a header row.
+type delimitedRenderer struct {
+	comma rune
+}
+
+func (r delimitedRenderer) Render(w io.Writer, v interface{}) error {
+	t, err := flatten(v)
+	if err != nil {
+		return err
+	}
+	if len(columns) > 0 {
+		t = t.selectColumns(columns)
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = r.comma
+	if err := cw.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// selectColumns returns a table with only the named columns, in that order.
+// Unknown names produce empty columns so the layout stays predictable.
+func (t *Table) selectColumns(names []string) *Table {
+	index := make(map[string]int, len(t.Headers))
+	for i, h := range t.Headers {
+		index[h] = i
+	}
+	out := &Table{Headers: names}
+	for _, row := range t.Rows {
+		sel := make([]string, len(names))
+		for i, n := range names {
+			if j, ok := index[n]; ok && j < len(row) {
+				sel[i] = row[j]
+			}
+		}
+		out.Rows = append(out.Rows, sel)
+	}
+	return out
+}
+
+// flatten turns a result into one row per record. Tasks and time entries
+// have dedicated layouts; anything else is flattened from its JSON form with
+// dot-separated column names.
+func flatten(v interface{}) (*Table, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && rv.Kind() != reflect.Ptr {
+		switch x := rv.Interface().(type) {
+		case api.TasksResponse:
+			return taskRows(x.Tasks), nil
+		case []api.Task:
+			return taskRows(x), nil
+		case api.Task:
+			return taskRows([]api.Task{x}), nil
+		case api.TimeEntriesResponse:
+			return timeEntryRows(x.Data), nil
+		case []api.TimeEntry:
+			return timeEntryRows(x), nil
+		case api.SingleTimeEntryResponse:
+			return timeEntryRows([]api.TimeEntry{x.Data}), nil
+		case api.TimeEntry:
+			return timeEntryRows([]api.TimeEntry{x}), nil
+		}
+	}
+
+	records, err := Records(v)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	flat := make([]map[string]string, len(records))
+	for i, rec := range records {
+		flat[i] = map[string]string{}
+		if m, ok := rec.(map[string]interface{}); ok {
+			flattenInto(flat[i], "", m)
+		} else {
+			flat[i]["value"] = cellString(rec)
+		}
+		for k := range flat[i] {
+			seen[k] = true
+		}
+	}
+	t := &Table{Headers: sortedKeys(seen)}
+	for _, f := range flat {
+		row := make([]string, len(t.Headers))
+		for i, h := range t.Headers {
+			row[i] = f[h]
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+func flattenInto(dst map[string]string, prefix string, m map[string]interface{}) {
+	for k, val := range m {
+		key := prefix + k
+		if nested, ok := val.(map[string]interface{}); ok {
+			flattenInto(dst, key+".", nested)
+			continue
+		}
+		dst[key] = cellString(val)
+	}
+}
+
+// cellString formats a generic JSON value for a single cell. Arrays of
+// scalars, and of objects with a username or name, are joined with "; ".
+func cellString(v interface{}) string {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return scalarString(v)
+	}
+	parts := make([]string, 0, len(arr))
+	for _, el := range arr {
+		switch x := el.(type) {
+		case map[string]interface{}:
+			label := firstString(x, "username", "name", "id")
+			if label == "" {
+				return scalarString(v)
+			}
+			parts = append(parts, label)
+		case []interface{}:
+			return scalarString(v)
+		default:
+			parts = append(parts, scalarString(x))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s := scalarString(m[k]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+var taskColumns = []string{
+	"id", "custom_id", "name", "status", "priority", "assignees", "tags",
+	"due_date", "start_date", "date_created", "date_updated", "date_closed",
+	"time_estimate_ms", "points", "parent", "list_id", "list", "folder_id",
+	"folder", "space_id", "url",
+}
+
+// taskRows flattens tasks into rows. Custom fields become "cf:<name>"
+// columns after the fixed ones, in order of first appearance.
+func taskRows(tasks []api.Task) *Table {
+	var cfNames []string
+	cfSeen := map[string]bool{}
+	for i := range tasks {
+		for _, cf := range tasks[i].CustomFields {
+			if !cfSeen[cf.Name] {
+				cfSeen[cf.Name] = true
+				cfNames = append(cfNames, cf.Name)
+			}
+		}
+	}
+
+	t := &Table{Headers: append([]string{}, taskColumns...)}
+	for _, n := range cfNames {
+		t.Headers = append(t.Headers, "cf:"+n)
+	}
+	for i := range tasks {
+		task := &tasks[i]
+		priority := ""
+		if task.Priority != nil {
+			priority = task.Priority.Priority
+		}
+		parent := ""
+		if task.Parent != nil {
+			parent = *task.Parent
+		}
+		assignees := make([]string, 0, len(task.Assignees))
+		for _, a := range task.Assignees {
+			assignees = append(assignees, a.Username)
+		}
+		tags := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			tags = append(tags, tag.Name)
+		}
+		row := []string{
+			task.ID,
+			task.CustomID,
+			task.Name,
+			task.Status.Status,
+			priority,
+			strings.Join(assignees, "; "),
+			strings.Join(tags, "; "),
+			isoMillis(task.DueDate),
+			isoMillis(task.StartDate),
+			isoMillis(task.DateCreated),
+			isoMillis(task.DateUpdated),
+			isoMillis(task.DateClosed),
+			task.TimeEstimate.String(),
+			task.Points.String(),
+			parent,
+			task.List.ID,
+			task.List.Name,
+			task.Folder.ID,
+			task.Folder.Name,
+			task.Space.ID,
+			task.URL,
+		}
+		values := make(map[string]string, len(task.CustomFields))
+		for _, cf := range task.CustomFields {
+			values[cf.Name] = customFieldString(cf)
+		}
+		for _, n := range cfNames {
+			row = append(row, values[n])
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func timeEntryRows(entries []api.TimeEntry) *Table {
+	t := &Table{Headers: []string{
+		"id", "task_id", "task", "user_id", "user", "start", "end",
+		"duration", "duration_ms", "billable", "description", "tags", "task_url",
+	}}
+	for i := range entries {
+		e := &entries[i]
+		tags := make([]string, 0, len(e.Tags))
+		for _, tag := range e.Tags {
+			tags = append(tags, tag.Name)
+		}
+		duration := ""
+		if ms, err := strconv.ParseInt(e.Duration, 10, 64); err == nil && ms >= 0 {
+			d := time.Duration(ms) * time.Millisecond
+			duration = fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+		}
+		var taskID, taskName, userID, username string
+		if e.Task != nil {
+			taskID, taskName = e.Task.ID, e.Task.Name
+		}
+		if e.User != nil {
+			userID, username = strconv.Itoa(e.User.ID), e.User.Username
+		}
+		t.Rows = append(t.Rows, []string{
+			e.ID,
+			taskID,
+			taskName,
+			userID,
+			username,
+			isoMillis(e.Start),
+			isoMillis(e.End),
+			duration,
+			e.Duration,
+			strconv.FormatBool(e.Billable),
+			e.Description,
+			strings.Join(tags, "; "),
+			e.TaskURL,
+		})
+	}
+	return t
+}
+
+// customFieldString renders a task's custom field value, resolving dropdown
+// and label option IDs to option names where the type config allows.
+func customFieldString(cf api.CustomField) string {
+	if cf.Value == nil {
+		return ""
+	}
+	generic, err := Generic(cf.Value)
+	if err != nil {
+		return ""
+	}
+	switch cf.Type {
+	case "drop_down":
+		if name := optionName(cf.TypeConfig, generic); name != "" {
+			return name
+		}
+	case "labels":
+		if ids, ok := generic.([]interface{}); ok {
+			names := make([]string, 0, len(ids))
+			for _, id := range ids {
+				if name := optionName(cf.TypeConfig, id); name != "" {
+					names = append(names, name)
+				} else {
+					names = append(names, scalarString(id))
+				}
+			}
+			return strings.Join(names, "; ")
+		}
+	case "date":
+		return isoMillis(scalarString(generic))
+	}
+	return cellString(generic)
+}
+
+// optionName looks up a dropdown or label option by ID or orderindex.
+func optionName(typeConfig, value interface{}) string {
+	tc, err := Generic(typeConfig)
+	if err != nil {
+		return ""
+	}
+	m, _ := tc.(map[string]interface{})
+	options, _ := m["options"].([]interface{})
+	want := scalarString(value)
+	for _, o := range options {
+		opt, _ := o.(map[string]interface{})
+		if scalarString(opt["id"]) == want || scalarString(opt["orderindex"]) == want {
+			return firstString(opt, "name", "label")
+		}
+	}
+	return ""
+}
+
+// isoMillis converts a Unix-millisecond timestamp string to RFC 3339 in UTC.
+func isoMillis(ms string) string {
+	if ms == "" {
+		return ""
+	}
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return ms
+	}
+	return time.UnixMilli(n).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,157 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+func readCSV(t *testing.T, s string, comma rune) []map[string]string {
+	t.Helper()
+	r := csv.NewReader(strings.NewReader(s))
+	r.Comma = comma
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v\n%s", err, s)
+	}
+	if len(rows) == 0 {
+		t.Fatal("missing header row")
+	}
+	var out []map[string]string
+	for _, row := range rows[1:] {
+		m := map[string]string{}
+		for i, h := range rows[0] {
+			m[h] = row[i]
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestCSVTasks(t *testing.T) {
+	estimate := api.FlexInt(7200000)
+	task := api.Task{
+		ID:           "abc",
+		Name:         "Write, docs",
+		Status:       api.TaskStatus{Status: "open"},
+		Priority:     &api.TaskPriority{Priority: "high"},
+		Assignees:    []api.User{{Username: "alice"}, {Username: "bob"}},
+		Tags:         []api.TaskTag{{Name: "backend"}},
+		DueDate:      "1700000000000",
+		TimeEstimate: &estimate,
+		CustomFields: []api.CustomField{
+			{
+				Name:       "Team",
+				Type:       "drop_down",
+				TypeConfig: map[string]interface{}{"options": []interface{}{map[string]interface{}{"id": "o1", "name": "Platform", "orderindex": 0.0}}},
+				Value:      0.0,
+			},
+			{
+				Name:       "Areas",
+				Type:       "labels",
+				TypeConfig: map[string]interface{}{"options": []interface{}{map[string]interface{}{"id": "l1", "label": "API"}, map[string]interface{}{"id": "l2", "label": "UI"}}},
+				Value:      []interface{}{"l1", "l2"},
+			},
+			{Name: "Story Points", Type: "number", Value: "5"},
+		},
+	}
+	task.List.Name = "Sprint"
+
+	var buf bytes.Buffer
+	if err := (delimitedRenderer{comma: ','}).Render(&buf, &api.TasksResponse{Tasks: []api.Task{task, {ID: "def", Name: "Other"}}}); err != nil {
+		t.Fatal(err)
+	}
+	rows := readCSV(t, buf.String(), ',')
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	want := map[string]string{
+		"id":               "abc",
+		"name":             "Write, docs",
+		"status":           "open",
+		"priority":         "high",
+		"assignees":        "alice; bob",
+		"tags":             "backend",
+		"due_date":         "2023-11-14T22:13:20Z",
+		"time_estimate_ms": "7200000",
+		"list":             "Sprint",
+		"cf:Team":          "Platform",
+		"cf:Areas":         "API; UI",
+		"cf:Story Points":  "5",
+	}
+	for k, v := range want {
+		if rows[0][k] != v {
+			t.Errorf("%s = %q, want %q", k, rows[0][k], v)
+		}
+	}
+	if rows[1]["cf:Team"] != "" {
+		t.Errorf("expected empty custom field for second task, got %q", rows[1]["cf:Team"])
+	}
+}
+
+func TestTSVTimeEntries(t *testing.T) {
+	resp := &api.TimeEntriesResponse{Data: []api.TimeEntry{{
+		ID:       "te1",
+		Task:     &api.TimeEntryTask{ID: "t1", Name: "Fix bug"},
+		User:     &api.User{ID: 42, Username: "alice"},
+		Start:    "1700000000000",
+		Duration: "5430000",
+		Billable: true,
+		Tags:     []api.Tag{{Name: "billing"}, {Name: "client"}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := (delimitedRenderer{comma: '\t'}).Render(&buf, resp); err != nil {
+		t.Fatal(err)
+	}
+	rows := readCSV(t, buf.String(), '\t')
+	want := map[string]string{
+		"task_id":     "t1",
+		"task":        "Fix bug",
+		"user_id":     "42",
+		"user":        "alice",
+		"duration":    "1:30:30",
+		"duration_ms": "5430000",
+		"billable":    "true",
+		"tags":        "billing; client",
+	}
+	for k, v := range want {
+		if rows[0][k] != v {
+			t.Errorf("%s = %q, want %q", k, rows[0][k], v)
+		}
+	}
+}
+
+func TestCSVColumns(t *testing.T) {
+	defer SetColumns(nil)
+	SetColumns([]string{"name", " id", "missing"})
+
+	var buf bytes.Buffer
+	if err := (delimitedRenderer{comma: ','}).Render(&buf, &api.TasksResponse{Tasks: []api.Task{{ID: "abc", Name: "Task"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "name,id,missing\nTask,abc,\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCSVGeneric(t *testing.T) {
+	resp := &api.SpacesResponse{Spaces: []api.Space{{ID: "s1", Name: "Eng"}}}
+	resp.Spaces[0].Status.Status = "active"
+
+	var buf bytes.Buffer
+	if err := (delimitedRenderer{comma: ','}).Render(&buf, resp); err != nil {
+		t.Fatal(err)
+	}
+	rows := readCSV(t, buf.String(), ',')
+	if len(rows) != 1 || rows[0]["id"] != "s1" || rows[0]["status.status"] != "active" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	header := strings.SplitN(buf.String(), "\n", 2)[0]
+	if !strings.HasPrefix(header, "id,name,") {
+		t.Errorf("expected id and name first, got %q", header)
+	}
+}
@@ -0,0 +1,127 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+// RecordRenderer is implemented by line-oriented formats that print each
+// record of a listing on its own. Print splits results into records for
+// these formats, and paginated commands stream pages through PrintRecords
+// instead of buffering the whole listing.
+type RecordRenderer interface {
+	Renderer
+	RenderRecord(w io.Writer, record interface{}) error
+}
+
+type ndjsonRenderer struct{}
+
+func (r ndjsonRenderer) Render(w io.Writer, v interface{}) error {
+	records, err := Records(v)
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if err := r.RenderRecord(w, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderRecord writes record as a single line of compact JSON.
+func (ndjsonRenderer) RenderRecord(w io.Writer, record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// Streaming reports whether the selected format prints records one at a
+// time, so callers can print pages as they are fetched.
+func Streaming() bool {
+	_, ok := renderers[format].(RecordRenderer)
+	return ok
+}
+
+// PrintRecords prints each element of the slice items as a record using the
+// selected format. With a non-record format the slice is printed as a whole.
+// --query and --fields are applied to each record individually.
+func PrintRecords(items interface{}) error {
+	rr, ok := renderers[format].(RecordRenderer)
+	if !ok {
+		return Print(items)
+	}
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("PrintRecords: expected a slice, got %T", items)
+	}
+	records := make([]interface{}, rv.Len())
+	for i := range records {
+		records[i] = rv.Index(i).Interface()
+	}
+	return printRecords(rr, records)
+}
+
+func printRecords(rr RecordRenderer, records []interface{}) error {
+	for _, rec := range records {
+		rec, err := Transform(rec)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			continue
+		}
+		if err := rr.RenderRecord(os.Stdout, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Records splits a command result into the records a line-oriented format
+// prints. Arrays yield their elements; a listing envelope such as
+// {"tasks": [...], "last_page": true} yields the elements of its array;
+// anything else is a single record.
+func Records(v interface{}) ([]interface{}, error) {
+	g, err := Generic(v)
+	if err != nil {
+		return nil, err
+	}
+	switch x := g.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		return x, nil
+	case map[string]interface{}:
+		if key, ok := listingKey(x); ok {
+			arr, _ := x[key].([]interface{})
+			return arr, nil
+		}
+	}
+	return []interface{}{g}, nil
+}
+
+// listingKey finds the array in a listing envelope: exactly one array value
+// and no other values besides pagination metadata (booleans and numbers).
+func listingKey(m map[string]interface{}) (string, bool) {
+	key, found := "", false
+	for k, val := range m {
+		switch val.(type) {
+		case []interface{}:
+			if found {
+				return "", false
+			}
+			key, found = k, true
+		case bool, json.Number, nil:
+		default:
+			return "", false
+		}
+	}
+	return key, found
+}
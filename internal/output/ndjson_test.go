@@ -0,0 +1,112 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+func TestRecords(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  int
+	}{
+		{name: "task envelope", input: &api.TasksResponse{Tasks: []api.Task{{ID: "1"}, {ID: "2"}}, LastPage: true}, want: 2},
+		{name: "time entries", input: &api.TimeEntriesResponse{Data: []api.TimeEntry{{ID: "1"}}}, want: 1},
+		{name: "empty envelope", input: &api.CommentsResponse{Comments: []api.Comment{}}, want: 0},
+		{name: "plain array", input: []string{"a", "b", "c"}, want: 3},
+		{name: "single task", input: &api.Task{ID: "1", Tags: []api.TaskTag{}, Assignees: []api.User{}}, want: 1},
+		{name: "folder with lists is one record", input: &api.Folder{ID: "f1", Lists: []api.List{{ID: "l1"}}}, want: 1},
+		{name: "message", input: map[string]string{"status": "ok"}, want: 1},
+		{name: "nil", input: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Records(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("expected %d records, got %d: %v", tt.want, len(got), got)
+			}
+		})
+	}
+}
+
+func TestPrintNDJSON(t *testing.T) {
+	defer func() {
+		_ = SetFormat(DefaultFormat)
+		SetFields(nil)
+		_ = SetQuery("")
+	}()
+	if err := SetFormat("ndjson"); err != nil {
+		t.Fatal(err)
+	}
+	if !Streaming() {
+		t.Fatal("ndjson should be a streaming format")
+	}
+
+	resp := &api.TasksResponse{Tasks: []api.Task{
+		{ID: "t1", Name: "one", Status: api.TaskStatus{Status: "open"}},
+		{ID: "t2", Name: "two", Status: api.TaskStatus{Status: "done"}},
+		{ID: "t3", Name: "three", Status: api.TaskStatus{Status: "open"}},
+	}}
+
+	out := captureStdout(func() { Print(resp) })
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), out)
+	}
+	for _, line := range lines {
+		var task map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			t.Fatalf("line is not a JSON object: %q", line)
+		}
+		if strings.Contains(line, "\n  ") {
+			t.Errorf("line is not compact: %q", line)
+		}
+	}
+
+	// --query and --fields apply per record.
+	if err := SetQuery("[?status.status==open]"); err != nil {
+		t.Fatal(err)
+	}
+	SetFields([]string{"id"})
+	out = captureStdout(func() { Print(resp) })
+	if out != "{\"id\":\"t1\"}\n{\"id\":\"t3\"}\n" {
+		t.Errorf("unexpected filtered output: %q", out)
+	}
+}
+
+func TestPrintRecords(t *testing.T) {
+	defer func() { _ = SetFormat(DefaultFormat) }()
+
+	if err := SetFormat("ndjson"); err != nil {
+		t.Fatal(err)
+	}
+	var err error
+	out := captureStdout(func() {
+		err = PrintRecords([]api.Comment{{ID: "c1"}, {ID: "c2"}})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(out, "\n") != 2 || !strings.Contains(out, `"id":"c2"`) {
+		t.Errorf("unexpected output: %q", out)
+	}
+	if err := PrintRecords("not a slice"); err == nil {
+		t.Error("expected error for non-slice input")
+	}
+
+	// A non-record format prints the slice as a whole.
+	_ = SetFormat("json")
+	out = captureStdout(func() { _ = PrintRecords([]string{"a", "b"}) })
+	var arr []string
+	if err := json.Unmarshal([]byte(out), &arr); err != nil || len(arr) != 2 {
+		t.Errorf("expected a JSON array, got %q", out)
+	}
+}
@@ -3,27 +3,134 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 )
 
 type ErrorResponse struct {
-	Error string `json:"error"`
-	Code  string `json:"code"`
+	Error      string      `json:"error"`
+	Code       string      `json:"code"`
+	Candidates interface{} `json:"candidates,omitempty"`
 }
 
+// Renderer writes a command result to w in a specific output format.
+type Renderer interface {
+	Render(w io.Writer, v interface{}) error
+}
+
+// RendererFunc adapts a plain function to the Renderer interface.
+type RendererFunc func(w io.Writer, v interface{}) error
+
+func (f RendererFunc) Render(w io.Writer, v interface{}) error {
+	return f(w, v)
+}
+
+const DefaultFormat = "json"
+
+var (
+	renderers = map[string]Renderer{
+		"json":   RendererFunc(renderJSON),
+		"text":   RendererFunc(renderText),
+		"ndjson": ndjsonRenderer{},
+		"csv":    delimitedRenderer{comma: ','},
+		"tsv":    delimitedRenderer{comma: '\t'},
+	}
+	format = DefaultFormat
+)
+
+// Register adds or replaces the renderer used for the given format name.
+func Register(name string, r Renderer) {
+	renderers[name] = r
+}
+
+// Formats returns the names of all registered output formats, sorted.
+func Formats() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetFormat selects the renderer used by Print. An empty name selects the default.
+func SetFormat(name string) error {
+	if name == "" {
+		name = DefaultFormat
+	}
+	if _, ok := renderers[name]; !ok {
+		return fmt.Errorf("unknown output format %q (valid: %s)", name, strings.Join(Formats(), ", "))
+	}
+	format = name
+	return nil
+}
+
+// Format returns the currently selected output format.
+func Format() string {
+	return format
+}
+
+// Print applies the active --query and --fields settings to v and renders
+// the result to stdout using the selected output format. Record formats
+// such as ndjson apply them to each record instead of the whole result.
+//
+// A failed query or render is printed as a QUERY_ERROR or RENDER_ERROR and
+// returned, so that commands can return it and exit 1.
+func Print(v interface{}) error {
+	if rr, ok := renderers[format].(RecordRenderer); ok {
+		records, err := Records(v)
+		if err == nil {
+			err = printRecords(rr, records)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to render output: %w", err)
+			PrintError("RENDER_ERROR", err.Error())
+		}
+		return err
+	}
+	v, err := Transform(v)
+	if err != nil {
+		err = fmt.Errorf("failed to apply query: %w", err)
+		PrintError("QUERY_ERROR", err.Error())
+		return err
+	}
+	if err := renderers[format].Render(os.Stdout, v); err != nil {
+		err = fmt.Errorf("failed to render output: %w", err)
+		PrintError("RENDER_ERROR", err.Error())
+		return err
+	}
+	return nil
+}
+
+// JSON prints v as indented JSON regardless of the selected format.
 func JSON(v interface{}) {
+	if err := renderJSON(os.Stdout, v); err != nil {
+		PrintError("MARSHAL_ERROR", fmt.Sprintf("failed to marshal output: %v", err))
+	}
+}
+
+func renderJSON(w io.Writer, v interface{}) error {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		PrintError("MARSHAL_ERROR", fmt.Sprintf("failed to marshal output: %v", err))
-		return
+		return err
 	}
-	fmt.Println(string(data))
+	_, err = fmt.Fprintln(w, string(data))
+	return err
 }
 
 func PrintError(code, message string) {
+	PrintErrorCandidates(code, message, nil)
+}
+
+// PrintErrorCandidates prints an error together with the values the user
+// could have meant, such as the matches of an ambiguous name.
+func PrintErrorCandidates(code, message string, candidates interface{}) {
 	resp := ErrorResponse{
-		Error: message,
-		Code:  code,
+		Error:      message,
+		Code:       code,
+		Candidates: candidates,
 	}
 	data, _ := json.MarshalIndent(resp, "", "  ")
 	fmt.Fprintln(os.Stderr, string(data))
@@ -121,6 +121,34 @@ func TestPrintError(t *testing.T) {
 	}
 }
 
+func TestPrintFailure(t *testing.T) {
+	defer SetFields(nil)
+	tests := []struct {
+		name   string
+		fields []string
+		code   string
+	}{
+		{name: "render", code: "RENDER_ERROR"},
+		{name: "query", fields: []string{"id"}, code: "QUERY_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetFields(tt.fields)
+			var err error
+			out := captureStderr(func() {
+				err = Print(map[string]interface{}{"id": make(chan int)})
+			})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var result ErrorResponse
+			if jerr := json.Unmarshal([]byte(out), &result); jerr != nil || result.Code != tt.code {
+				t.Errorf("expected a %s, got %s", tt.code, out)
+			}
+		})
+	}
+}
+
 func TestJSON_GoldenFile(t *testing.T) {
 	input := map[string]interface{}{
 		"id":   "task_123",
@@ -0,0 +1,426 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query is a compiled path expression such as "tasks[?status.status==open].id".
+//
+// Supported steps:
+//
+//	name          object key; applied to an array it maps over the elements
+//	[n]           array index (negative counts from the end)
+//	[] or [*]     every element of an array, or every value of an object
+//	[?path]       keep elements where path is present and not false/empty
+//	[?path==v]    keep elements where path equals v (also !=, and ~= for substring)
+//
+// Steps are separated by dots; a leading dot is optional.
+type Query struct {
+	expr  string
+	steps []step
+}
+
+type stepKind int
+
+const (
+	stepKey stepKind = iota
+	stepIndex
+	stepWildcard
+	stepFilter
+)
+
+type step struct {
+	kind  stepKind
+	key   string
+	index int
+
+	// filter
+	path  []step
+	op    string
+	value string
+}
+
+var (
+	query  *Query
+	fields [][]string
+)
+
+// SetQuery compiles expr and applies it to everything printed by Print.
+// An empty expression disables querying.
+func SetQuery(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		query = nil
+		return nil
+	}
+	q, err := ParseQuery(expr)
+	if err != nil {
+		return err
+	}
+	query = q
+	return nil
+}
+
+// SetFields restricts everything printed by Print to the given dot-separated
+// paths. An empty list disables projection.
+func SetFields(paths []string) {
+	fields = nil
+	for _, p := range paths {
+		p = strings.TrimPrefix(strings.TrimSpace(p), ".")
+		if p == "" {
+			continue
+		}
+		fields = append(fields, strings.Split(p, "."))
+	}
+}
+
+// ParseQuery compiles a path expression.
+func ParseQuery(expr string) (*Query, error) {
+	steps, err := parseSteps(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	return &Query{expr: expr, steps: steps}, nil
+}
+
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Eval applies the query to a generic JSON value (as produced by Generic).
+func (q *Query) Eval(v interface{}) interface{} {
+	return evalSteps(v, q.steps)
+}
+
+func parseSteps(s string) ([]step, error) {
+	s = strings.TrimPrefix(s, ".")
+	var steps []step
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if s == "" || s[0] == '.' {
+				return nil, fmt.Errorf("empty path segment")
+			}
+		case '[':
+			end := matchingBracket(s)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '['")
+			}
+			st, err := parseBracket(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, st)
+			s = s[end+1:]
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			key := s[:end]
+			if key == "*" {
+				steps = append(steps, step{kind: stepWildcard})
+			} else {
+				steps = append(steps, step{kind: stepKey, key: key})
+			}
+			s = s[end:]
+		}
+	}
+	return steps, nil
+}
+
+func matchingBracket(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseBracket(inner string) (step, error) {
+	if inner == "" || inner == "*" {
+		return step{kind: stepWildcard}, nil
+	}
+	if strings.HasPrefix(inner, "?") {
+		return parseFilter(strings.TrimSpace(inner[1:]))
+	}
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return step{}, fmt.Errorf("invalid index %q", inner)
+	}
+	return step{kind: stepIndex, index: n}, nil
+}
+
+func parseFilter(expr string) (step, error) {
+	st := step{kind: stepFilter}
+	lhs := expr
+	for _, op := range []string{"==", "!=", "~="} {
+		if i := strings.Index(expr, op); i >= 0 {
+			st.op = op
+			lhs = strings.TrimSpace(expr[:i])
+			st.value = unquote(strings.TrimSpace(expr[i+len(op):]))
+			break
+		}
+	}
+	if lhs == "" {
+		return step{}, fmt.Errorf("empty filter path")
+	}
+	path, err := parseSteps(lhs)
+	if err != nil {
+		return step{}, err
+	}
+	st.path = path
+	return st, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func evalSteps(v interface{}, steps []step) interface{} {
+	for _, st := range steps {
+		v = evalStep(v, st)
+		if v == nil {
+			return nil
+		}
+	}
+	return v
+}
+
+func evalStep(v interface{}, st step) interface{} {
+	switch st.kind {
+	case stepKey:
+		switch x := v.(type) {
+		case map[string]interface{}:
+			return x[st.key]
+		case []interface{}:
+			out := make([]interface{}, 0, len(x))
+			for _, el := range x {
+				if r := evalStep(el, st); r != nil {
+					out = append(out, r)
+				}
+			}
+			return out
+		}
+	case stepIndex:
+		if x, ok := v.([]interface{}); ok {
+			i := st.index
+			if i < 0 {
+				i += len(x)
+			}
+			if i >= 0 && i < len(x) {
+				return x[i]
+			}
+		}
+	case stepWildcard:
+		switch x := v.(type) {
+		case []interface{}:
+			return x
+		case map[string]interface{}:
+			keys := make([]string, 0, len(x))
+			for k := range x {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			out := make([]interface{}, 0, len(x))
+			for _, k := range keys {
+				out = append(out, x[k])
+			}
+			return out
+		}
+	case stepFilter:
+		switch x := v.(type) {
+		case []interface{}:
+			out := make([]interface{}, 0, len(x))
+			for _, el := range x {
+				if st.matches(el) {
+					out = append(out, el)
+				}
+			}
+			return out
+		case map[string]interface{}:
+			if st.matches(x) {
+				return x
+			}
+		}
+	}
+	return nil
+}
+
+func (st step) matches(v interface{}) bool {
+	got := evalSteps(v, st.path)
+	switch st.op {
+	case "==":
+		return got != nil && scalarString(got) == st.value
+	case "!=":
+		return got == nil || scalarString(got) != st.value
+	case "~=":
+		return got != nil && strings.Contains(strings.ToLower(scalarString(got)), strings.ToLower(st.value))
+	}
+	switch x := got.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case string:
+		return x != ""
+	case []interface{}:
+		return len(x) > 0
+	}
+	return true
+}
+
+// Generic converts v to its generic JSON form (maps, slices, json.Number, ...).
+func Generic(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Transform applies the active --query and --fields settings to v. The
+// value is returned unchanged if neither is set.
+func Transform(v interface{}) (interface{}, error) {
+	if query == nil && len(fields) == 0 {
+		return v, nil
+	}
+	g, err := Generic(v)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		g = query.Eval(g)
+	}
+	if len(fields) > 0 {
+		g = projectRecords(g, fields)
+	}
+	return g, nil
+}
+
+// projectRecords applies field projection to each record of v. Arrays are
+// projected element-wise; a response envelope such as {"tasks": [...]} is
+// projected inside its array unless a field names one of its own keys.
+func projectRecords(v interface{}, paths [][]string) interface{} {
+	switch x := v.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, el := range x {
+			out[i] = project(el, paths)
+		}
+		return out
+	case map[string]interface{}:
+		if key, ok := envelopeKey(x, paths); ok {
+			out := make(map[string]interface{}, len(x))
+			for k, val := range x {
+				out[k] = val
+			}
+			out[key] = projectRecords(x[key], paths)
+			return out
+		}
+		return project(x, paths)
+	}
+	return v
+}
+
+func envelopeKey(m map[string]interface{}, paths [][]string) (string, bool) {
+	for _, p := range paths {
+		if _, ok := m[p[0]]; ok {
+			return "", false
+		}
+	}
+	key, found := "", false
+	for k, val := range m {
+		if _, ok := val.([]interface{}); ok {
+			if found {
+				return "", false
+			}
+			key, found = k, true
+		}
+	}
+	return key, found
+}
+
+func project(v interface{}, paths [][]string) interface{} {
+	src, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	dst := map[string]interface{}{}
+	for _, p := range paths {
+		pick(dst, src, p)
+	}
+	return dst
+}
+
+// pick copies the value at path from src into dst, preserving nesting.
+// Arrays along the path are projected element-wise.
+func pick(dst, src map[string]interface{}, path []string) {
+	val, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	key := path[0]
+	if len(path) == 1 {
+		dst[key] = val
+		return
+	}
+	switch x := val.(type) {
+	case map[string]interface{}:
+		sub, _ := dst[key].(map[string]interface{})
+		if sub == nil {
+			sub = map[string]interface{}{}
+		}
+		pick(sub, x, path[1:])
+		dst[key] = sub
+	case []interface{}:
+		subs, _ := dst[key].([]interface{})
+		if len(subs) != len(x) {
+			subs = make([]interface{}, len(x))
+		}
+		for i, el := range x {
+			m, ok := el.(map[string]interface{})
+			if !ok {
+				subs[i] = el
+				continue
+			}
+			sub, _ := subs[i].(map[string]interface{})
+			if sub == nil {
+				sub = map[string]interface{}{}
+			}
+			pick(sub, m, path[1:])
+			subs[i] = sub
+		}
+		dst[key] = subs
+	}
+}
@@ -0,0 +1,172 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const queryFixture = `{
+  "tasks": [
+    {"id": "a1", "name": "Write docs", "status": {"status": "open", "color": "#000"}, "assignees": [{"id": 1, "username": "alice"}, {"id": 2, "username": "bob"}], "points": 3},
+    {"id": "b2", "name": "Ship release", "status": {"status": "done", "color": "#0f0"}, "assignees": [], "points": 5},
+    {"id": "c3", "name": "Fix docs typo", "status": {"status": "open", "color": "#000"}, "assignees": [{"id": 2, "username": "bob"}]}
+  ],
+  "last_page": true
+}`
+
+func fixture(t *testing.T) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(queryFixture), &v); err != nil {
+		t.Fatal(err)
+	}
+	g, err := Generic(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func toJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestQueryEval(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{name: "key", expr: "last_page", want: `true`},
+		{name: "leading dot", expr: ".last_page", want: `true`},
+		{name: "index", expr: "tasks[1].id", want: `"b2"`},
+		{name: "negative index", expr: "tasks[-1].id", want: `"c3"`},
+		{name: "map over array", expr: "tasks.id", want: `["a1","b2","c3"]`},
+		{name: "wildcard", expr: "tasks[*].name", want: `["Write docs","Ship release","Fix docs typo"]`},
+		{name: "nested map", expr: "tasks.status.status", want: `["open","done","open"]`},
+		{name: "filter equals", expr: "tasks[?status.status==open].id", want: `["a1","c3"]`},
+		{name: "filter quoted", expr: `tasks[?name=="Ship release"].id`, want: `["b2"]`},
+		{name: "filter not equals", expr: "tasks[?status.status!=open].id", want: `["b2"]`},
+		{name: "filter number", expr: "tasks[?points==5].id", want: `["b2"]`},
+		{name: "filter contains", expr: "tasks[?name~=DOCS].id", want: `["a1","c3"]`},
+		{name: "filter truthy", expr: "tasks[?assignees].id", want: `["a1","c3"]`},
+		{name: "filter nested array", expr: "tasks[?assignees.username~=alice].id", want: `["a1"]`},
+		{name: "missing key", expr: "nope", want: `null`},
+		{name: "index out of range", expr: "tasks[10]", want: `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.expr)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			got := toJSON(t, q.Eval(fixture(t)))
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, expr := range []string{"tasks[", "tasks[abc]", "tasks..id", "tasks[?]", "tasks."} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseQuery(expr); err == nil {
+				t.Errorf("expected error for %q", expr)
+			}
+		})
+	}
+}
+
+func TestTransformFields(t *testing.T) {
+	defer SetFields(nil)
+
+	tests := []struct {
+		name   string
+		fields string
+		input  interface{}
+		want   string
+	}{
+		{
+			name:   "envelope is projected per record",
+			fields: "id,status.status",
+			input:  fixture(t),
+			want:   `{"last_page":true,"tasks":[{"id":"a1","status":{"status":"open"}},{"id":"b2","status":{"status":"done"}},{"id":"c3","status":{"status":"open"}}]}`,
+		},
+		{
+			name:   "array inside record",
+			fields: "id,assignees.username",
+			input:  map[string]interface{}{"id": "x", "assignees": []interface{}{map[string]interface{}{"id": 1, "username": "alice"}}},
+			want:   `{"assignees":[{"username":"alice"}],"id":"x"}`,
+		},
+		{
+			name:   "top-level key disables envelope",
+			fields: "last_page",
+			input:  fixture(t),
+			want:   `{"last_page":true}`,
+		},
+		{
+			name:   "top-level array",
+			fields: "name",
+			input:  []interface{}{map[string]interface{}{"id": "1", "name": "a"}},
+			want:   `[{"name":"a"}]`,
+		},
+		{
+			name:   "missing fields are omitted",
+			fields: "id,nope.deeper",
+			input:  map[string]interface{}{"id": "1", "name": "a"},
+			want:   `{"id":"1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetFields(strings.Split(tt.fields, ","))
+			got, err := Transform(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := toJSON(t, got); s != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, s)
+			}
+		})
+	}
+}
+
+func TestTransformQueryThenFields(t *testing.T) {
+	defer func() {
+		SetFields(nil)
+		_ = SetQuery("")
+	}()
+
+	if err := SetQuery("tasks[?status.status==open]"); err != nil {
+		t.Fatal(err)
+	}
+	SetFields([]string{"id", "name"})
+	got, err := Transform(fixture(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"id":"a1","name":"Write docs"},{"id":"c3","name":"Fix docs typo"}]`
+	if s := toJSON(t, got); s != want {
+		t.Errorf("expected %s, got %s", want, s)
+	}
+}
+
+func TestTransformPassthrough(t *testing.T) {
+	in := map[string]string{"id": "1"}
+	got, err := Transform(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.(map[string]string); !ok {
+		t.Errorf("expected value to pass through unchanged, got %T", got)
+	}
+}
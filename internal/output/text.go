@@ -0,0 +1,512 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// Table is a rectangular, human-readable view of a command result.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Write prints the table as aligned columns.
+func (t *Table) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Headers, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteVertical prints each row as a block of "HEADER: value" lines, which
+// reads better than a one-row table for single resources.
+func (t *Table) WriteVertical(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	for i, row := range t.Rows {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		for j, h := range t.Headers {
+			if j < len(row) {
+				fmt.Fprintf(tw, "%s:\t%s\n", h, row[j])
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// TextLayout builds a table for a known result type. ok is false if v is not
+// a type the layout understands.
+type TextLayout func(v interface{}) (t *Table, single bool, ok bool)
+
+var textLayouts = []TextLayout{builtinLayout}
+
+// RegisterTextLayout adds a table layout that is tried before the built-in ones.
+func RegisterTextLayout(l TextLayout) {
+	textLayouts = append([]TextLayout{l}, textLayouts...)
+}
+
+// TextWriter is implemented by results with a text form of their own that
+// is not a table, such as a hierarchy tree.
+type TextWriter interface {
+	WriteText(w io.Writer) error
+}
+
+// renderText prints known API types as aligned tables and falls back to a
+// generic rendering of the JSON form for everything else.
+func renderText(w io.Writer, v interface{}) error {
+	if tw, ok := v.(TextWriter); ok {
+		return tw.WriteText(w)
+	}
+	for _, layout := range textLayouts {
+		if t, single, ok := layout(v); ok {
+			if single {
+				return t.WriteVertical(w)
+			}
+			return t.Write(w)
+		}
+	}
+	return renderGenericText(w, v)
+}
+
+func builtinLayout(v interface{}) (*Table, bool, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false, false
+		}
+		rv = rv.Elem()
+	}
+
+	switch x := rv.Interface().(type) {
+	case api.TasksResponse:
+		return taskTable(x.Tasks), false, true
+	case api.Task:
+		return taskTable([]api.Task{x}), true, true
+	case api.ListsResponse:
+		return listTable(x.Lists), false, true
+	case api.List:
+		return listTable([]api.List{x}), true, true
+	case api.SpacesResponse:
+		return spaceTable(x.Spaces), false, true
+	case api.Space:
+		return spaceTable([]api.Space{x}), true, true
+	case api.FoldersResponse:
+		return folderTable(x.Folders), false, true
+	case api.Folder:
+		return folderTable([]api.Folder{x}), true, true
+	case api.TimeEntriesResponse:
+		return timeEntryTable(x.Data), false, true
+	case api.SingleTimeEntryResponse:
+		return timeEntryTable([]api.TimeEntry{x.Data}), true, true
+	case api.TimeEntry:
+		return timeEntryTable([]api.TimeEntry{x}), true, true
+	case api.CommentsResponse:
+		return commentTable(x.Comments), false, true
+	case api.Comment:
+		return commentTable([]api.Comment{x}), true, true
+	case api.WorkspacesResponse:
+		return workspaceTable(x.Teams), false, true
+	case api.MembersResponse:
+		return memberTable(x.Members), false, true
+	case api.User:
+		return userTable([]api.User{x}), true, true
+	case api.UserResponse:
+		return userTable([]api.User{x.User}), true, true
+	case api.TagsResponse:
+		return tagTable(x.Tags), false, true
+	case api.CustomFieldsResponse:
+		return customFieldTable(x.Fields), false, true
+	case api.ViewsResponse:
+		return viewTable(x.Views), false, true
+	case api.ViewResponse:
+		return viewTable([]api.View{x.View}), true, true
+	case api.GoalsResponse:
+		return goalTable(x.Goals), false, true
+	case api.GoalResponse:
+		return goalTable([]api.Goal{x.Goal}), true, true
+	case api.WebhooksResponse:
+		return webhookTable(x.Webhooks), false, true
+	case api.DocsResponse:
+		return docTable(x.Docs), false, true
+	case api.Doc:
+		return docTable([]api.Doc{x}), true, true
+	case api.TaskTemplatesResponse:
+		return templateTable(x.Templates), false, true
+	}
+	return nil, false, false
+}
+
+func taskTable(tasks []api.Task) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "STATUS", "PRIORITY", "ASSIGNEES", "DUE", "LIST"}}
+	for i := range tasks {
+		task := &tasks[i]
+		priority := ""
+		if task.Priority != nil {
+			priority = task.Priority.Priority
+		}
+		assignees := make([]string, 0, len(task.Assignees))
+		for _, a := range task.Assignees {
+			assignees = append(assignees, a.Username)
+		}
+		t.Rows = append(t.Rows, []string{
+			task.ID,
+			task.Name,
+			task.Status.Status,
+			priority,
+			strings.Join(assignees, ", "),
+			formatMillis(task.DueDate),
+			task.List.Name,
+		})
+	}
+	return t
+}
+
+func listTable(lists []api.List) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "TASKS", "FOLDER", "SPACE", "DUE"}}
+	for i := range lists {
+		l := &lists[i]
+		t.Rows = append(t.Rows, []string{
+			l.ID,
+			l.Name,
+			strconv.Itoa(l.TaskCount),
+			l.Folder.Name,
+			l.Space.Name,
+			formatMillis(l.DueDate),
+		})
+	}
+	return t
+}
+
+func spaceTable(spaces []api.Space) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "PRIVATE", "MULTIPLE ASSIGNEES"}}
+	for i := range spaces {
+		s := &spaces[i]
+		t.Rows = append(t.Rows, []string{
+			s.ID,
+			s.Name,
+			strconv.FormatBool(s.Private),
+			strconv.FormatBool(s.Multiple),
+		})
+	}
+	return t
+}
+
+func folderTable(folders []api.Folder) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "TASKS", "LISTS", "SPACE", "HIDDEN"}}
+	for i := range folders {
+		f := &folders[i]
+		t.Rows = append(t.Rows, []string{
+			f.ID,
+			f.Name,
+			f.TaskCount,
+			strconv.Itoa(len(f.Lists)),
+			f.Space.Name,
+			strconv.FormatBool(f.Hidden),
+		})
+	}
+	return t
+}
+
+func timeEntryTable(entries []api.TimeEntry) *Table {
+	t := &Table{Headers: []string{"ID", "TASK", "USER", "START", "DURATION", "BILLABLE", "DESCRIPTION"}}
+	for i := range entries {
+		e := &entries[i]
+		var task, user string
+		if e.Task != nil {
+			task = e.Task.Name
+		}
+		if e.User != nil {
+			user = e.User.Username
+		}
+		t.Rows = append(t.Rows, []string{
+			e.ID,
+			task,
+			user,
+			formatMillis(e.Start),
+			formatDurationMillis(e.Duration),
+			strconv.FormatBool(e.Billable),
+			truncate(e.Description, 60),
+		})
+	}
+	return t
+}
+
+func commentTable(comments []api.Comment) *Table {
+	t := &Table{Headers: []string{"ID", "USER", "DATE", "TEXT"}}
+	for i := range comments {
+		c := &comments[i]
+		t.Rows = append(t.Rows, []string{
+			c.ID,
+			c.User.Username,
+			formatMillis(c.Date),
+			truncate(c.CommentText, 80),
+		})
+	}
+	return t
+}
+
+func workspaceTable(teams []api.Workspace) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "MEMBERS"}}
+	for i := range teams {
+		w := &teams[i]
+		t.Rows = append(t.Rows, []string{w.ID, w.Name, strconv.Itoa(len(w.Members))})
+	}
+	return t
+}
+
+func memberTable(members []api.Member) *Table {
+	t := &Table{Headers: []string{"ID", "USERNAME", "EMAIL"}}
+	for _, m := range members {
+		t.Rows = append(t.Rows, []string{strconv.Itoa(m.ID), m.Username, m.Email})
+	}
+	return t
+}
+
+func userTable(users []api.User) *Table {
+	t := &Table{Headers: []string{"ID", "USERNAME", "EMAIL"}}
+	for _, u := range users {
+		t.Rows = append(t.Rows, []string{strconv.Itoa(u.ID), u.Username, u.Email})
+	}
+	return t
+}
+
+func tagTable(tags []api.Tag) *Table {
+	t := &Table{Headers: []string{"NAME", "FG", "BG"}}
+	for _, tag := range tags {
+		t.Rows = append(t.Rows, []string{tag.Name, tag.TagFg, tag.TagBg})
+	}
+	return t
+}
+
+func customFieldTable(fields []api.CustomField) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "TYPE", "REQUIRED"}}
+	for i := range fields {
+		f := &fields[i]
+		t.Rows = append(t.Rows, []string{f.ID, f.Name, f.Type, strconv.FormatBool(f.Required)})
+	}
+	return t
+}
+
+func viewTable(views []api.View) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "TYPE"}}
+	for i := range views {
+		v := &views[i]
+		t.Rows = append(t.Rows, []string{v.ID, v.Name, v.Type})
+	}
+	return t
+}
+
+func goalTable(goals []api.Goal) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "DUE", "PERCENT"}}
+	for i := range goals {
+		g := &goals[i]
+		t.Rows = append(t.Rows, []string{
+			g.ID,
+			g.Name,
+			formatMillis(g.DueDate),
+			strconv.FormatFloat(g.PercentCompleted, 'f', -1, 64),
+		})
+	}
+	return t
+}
+
+func webhookTable(webhooks []api.Webhook) *Table {
+	t := &Table{Headers: []string{"ID", "ENDPOINT", "EVENTS"}}
+	for i := range webhooks {
+		wh := &webhooks[i]
+		t.Rows = append(t.Rows, []string{wh.ID, wh.Endpoint, strings.Join(wh.Events, ",")})
+	}
+	return t
+}
+
+func docTable(docs []api.Doc) *Table {
+	t := &Table{Headers: []string{"ID", "NAME", "CREATED", "VISIBILITY"}}
+	for i := range docs {
+		d := &docs[i]
+		t.Rows = append(t.Rows, []string{d.ID, d.Name, formatMillis(d.DateCreated.String()), d.Visibility})
+	}
+	return t
+}
+
+func templateTable(templates []api.TaskTemplate) *Table {
+	t := &Table{Headers: []string{"ID", "NAME"}}
+	for _, tmpl := range templates {
+		t.Rows = append(t.Rows, []string{tmpl.ID, tmpl.Name})
+	}
+	return t
+}
+
+// renderGenericText renders any value via its JSON form: arrays of objects
+// become tables, objects become "key: value" lines.
+func renderGenericText(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	switch x := generic.(type) {
+	case []interface{}:
+		return genericTable(x).Write(w)
+	case map[string]interface{}:
+		// A wrapper like {"goals": [...]} is shown as the table it wraps.
+		if len(x) == 1 {
+			for _, inner := range x {
+				if arr, ok := inner.([]interface{}); ok {
+					return genericTable(arr).Write(w)
+				}
+			}
+		}
+		keys := sortedKeys(x)
+		t := &Table{Headers: keys, Rows: [][]string{make([]string, len(keys))}}
+		for i, k := range keys {
+			t.Rows[0][i] = scalarString(x[k])
+		}
+		return t.WriteVertical(w)
+	default:
+		_, err := fmt.Fprintln(w, scalarString(x))
+		return err
+	}
+}
+
+func genericTable(items []interface{}) *Table {
+	seen := map[string]bool{}
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			for k, val := range m {
+				if isScalar(val) {
+					seen[k] = true
+				}
+			}
+		}
+	}
+	keys := sortedKeys(seen)
+	t := &Table{}
+	if len(keys) == 0 {
+		t.Headers = []string{"VALUE"}
+		for _, item := range items {
+			t.Rows = append(t.Rows, []string{scalarString(item)})
+		}
+		return t
+	}
+	for _, k := range keys {
+		t.Headers = append(t.Headers, strings.ToUpper(k))
+	}
+	for _, item := range items {
+		m, _ := item.(map[string]interface{})
+		row := make([]string, len(keys))
+		for i, k := range keys {
+			row[i] = scalarString(m[k])
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// sortedKeys returns map keys sorted with "id" and "name" first.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	rank := func(k string) int {
+		switch k {
+		case "id":
+			return 0
+		case "name":
+			return 1
+		}
+		return 2
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := rank(keys[i]), rank(keys[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// scalarString formats a generic JSON value for a table cell.
+func scalarString(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case json.Number:
+		return x.String()
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	default:
+		data, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		return string(data)
+	}
+}
+
+// formatMillis converts a ClickUp Unix-millisecond timestamp string to local time.
+func formatMillis(ms string) string {
+	if ms == "" {
+		return ""
+	}
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return ms
+	}
+	return time.UnixMilli(n).Local().Format("2006-01-02 15:04")
+}
+
+// formatDurationMillis converts a millisecond duration string to a Go duration.
+// Negative durations (running timers) are shown as "running".
+func formatDurationMillis(ms string) string {
+	if ms == "" {
+		return ""
+	}
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return ms
+	}
+	if n < 0 {
+		return "running"
+	}
+	return (time.Duration(n) * time.Millisecond).Round(time.Second).String()
+}
+
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+func TestSetFormat(t *testing.T) {
+	defer func() { _ = SetFormat(DefaultFormat) }()
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{name: "json", format: "json", want: "json"},
+		{name: "text", format: "text", want: "text"},
+		{name: "empty selects default", format: "", want: DefaultFormat},
+		{name: "unknown", format: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = SetFormat(DefaultFormat)
+			err := SetFormat(tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if Format() != DefaultFormat {
+					t.Errorf("format changed on error: %q", Format())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if Format() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, Format())
+			}
+		})
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     interface{}
+		wantLines []string
+		absent    []string
+	}{
+		{
+			name: "task list",
+			input: &api.TasksResponse{Tasks: []api.Task{
+				{
+					ID:        "abc",
+					Name:      "Write docs",
+					Status:    api.TaskStatus{Status: "in progress"},
+					Priority:  &api.TaskPriority{Priority: "high"},
+					Assignees: []api.User{{Username: "alice"}, {Username: "bob"}},
+				},
+				{ID: "def", Name: "Ship it", Status: api.TaskStatus{Status: "open"}},
+			}},
+			wantLines: []string{"ID", "NAME", "STATUS", "abc", "Write docs", "in progress", "high", "alice, bob", "def"},
+			absent:    []string{"{", "\"tasks\""},
+		},
+		{
+			name:      "single task is vertical",
+			input:     &api.Task{ID: "abc", Name: "Write docs", Status: api.TaskStatus{Status: "open"}},
+			wantLines: []string{"ID:", "abc", "NAME:", "Write docs", "STATUS:", "open"},
+		},
+		{
+			name: "time entries",
+			input: &api.TimeEntriesResponse{Data: []api.TimeEntry{
+				{
+					ID:       "te1",
+					Task:     &api.TimeEntryTask{ID: "t1", Name: "Fix bug"},
+					User:     &api.User{ID: 1, Username: "alice"},
+					Duration: "5400000",
+				},
+			}},
+			wantLines: []string{"DURATION", "te1", "Fix bug", "alice", "1h30m0s"},
+		},
+		{
+			name:      "running timer",
+			input:     &api.SingleTimeEntryResponse{Data: api.TimeEntry{ID: "te2", Duration: "-1676000000000"}},
+			wantLines: []string{"te2", "running"},
+		},
+		{
+			name:      "comments",
+			input:     &api.CommentsResponse{Comments: []api.Comment{{ID: "c1", CommentText: "looks\ngood", User: api.User{Username: "bob"}}}},
+			wantLines: []string{"c1", "bob", "looks good"},
+		},
+		{
+			name:      "generic message",
+			input:     map[string]string{"message": "task deleted", "id": "abc"},
+			wantLines: []string{"id:", "abc", "message:", "task deleted"},
+		},
+		{
+			name: "generic wrapped array",
+			input: map[string]interface{}{"goals_like": []map[string]interface{}{
+				{"id": "g1", "name": "Q1", "nested": map[string]string{"x": "y"}},
+			}},
+			wantLines: []string{"ID", "NAME", "g1", "Q1"},
+			absent:    []string{"NESTED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := renderText(&buf, tt.input); err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			out := buf.String()
+			for _, want := range tt.wantLines {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("output unexpectedly contains %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestTableAlignment(t *testing.T) {
+	table := &Table{
+		Headers: []string{"ID", "NAME"},
+		Rows:    [][]string{{"1", "short"}, {"12345", "longer name"}},
+	}
+	var buf bytes.Buffer
+	if err := table.Write(&buf); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	col := strings.Index(lines[0], "NAME")
+	for _, line := range lines[1:] {
+		if strings.Index(line, "short") != col && strings.Index(line, "longer") != col {
+			t.Errorf("column not aligned at %d: %q", col, line)
+		}
+	}
+}
+
+func TestPrintUsesSelectedFormat(t *testing.T) {
+	defer func() { _ = SetFormat(DefaultFormat) }()
+
+	if err := SetFormat("text"); err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(func() {
+		Print(&api.SpacesResponse{Spaces: []api.Space{{ID: "s1", Name: "Engineering"}}})
+	})
+	if strings.HasPrefix(strings.TrimSpace(out), "{") {
+		t.Errorf("expected text output, got JSON:\n%s", out)
+	}
+	if !strings.Contains(out, "Engineering") {
+		t.Errorf("missing space name:\n%s", out)
+	}
+}
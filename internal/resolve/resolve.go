@@ -0,0 +1,417 @@
+// Package resolve turns the names people use for ClickUp objects (spaces,
+// folders, lists, users, statuses) into the IDs the API expects.
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// Candidate is an object a reference could mean.
+type Candidate struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Path  string `json:"path,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Error reports a reference that matched no object, or more than one.
+// Candidates holds the matches when ambiguous, or the valid choices when
+// there are few enough to list.
+type Error struct {
+	Kind       string
+	Ref        string
+	Ambiguous  bool
+	Candidates []Candidate
+}
+
+func (e *Error) Error() string {
+	if e.Ambiguous {
+		return fmt.Sprintf("%s %q is ambiguous: %d matches; use an ID or a longer path", e.Kind, e.Ref, len(e.Candidates))
+	}
+	return fmt.Sprintf("no %s named %q", e.Kind, e.Ref)
+}
+
+// Code is the CLI error code for e: AMBIGUOUS or NOT_FOUND.
+func (e *Error) Code() string {
+	if e.Ambiguous {
+		return "AMBIGUOUS"
+	}
+	return "NOT_FOUND"
+}
+
+// IsID reports whether ref is a numeric ID rather than a name. Space,
+// folder, list and user IDs are all numeric.
+func IsID(ref string) bool {
+	if ref == "" {
+		return false
+	}
+	for _, r := range ref {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolver looks names up through the API, remembering what it fetched for
+// the rest of the command. It is safe for concurrent use.
+type Resolver struct {
+	Client      api.ClientInterface
+	WorkspaceID string
+
+	mu         sync.Mutex
+	spaces     []api.Space
+	folders    map[string][]api.Folder
+	folderless map[string][]api.List
+	statuses   map[string][]api.TaskStatus
+}
+
+// New returns a Resolver for the workspace workspaceID.
+func New(client api.ClientInterface, workspaceID string) *Resolver {
+	return &Resolver{Client: client, WorkspaceID: workspaceID}
+}
+
+// Space returns the ID of the space named ref, or ref itself if it is an ID.
+func (r *Resolver) Space(ctx context.Context, ref string) (string, error) {
+	if ref == "" || IsID(ref) {
+		return ref, nil
+	}
+	spaces, err := r.listSpaces(ctx)
+	if err != nil {
+		return "", err
+	}
+	var all, matches []Candidate
+	for _, s := range spaces {
+		c := Candidate{ID: s.ID, Name: s.Name}
+		all = append(all, c)
+		if strings.EqualFold(s.Name, ref) {
+			matches = append(matches, c)
+		}
+	}
+	return pick("space", ref, matches, all)
+}
+
+// Folder returns the ID of the folder named ref. ref may be a path such as
+// "Engineering/Backend". The search covers spaceID (an ID or name) or, when
+// it is empty, every space in the workspace.
+func (r *Resolver) Folder(ctx context.Context, ref, spaceID string) (string, error) {
+	if ref == "" || IsID(ref) {
+		return ref, nil
+	}
+	spaces, err := r.scope(ctx, spaceID)
+	if err != nil {
+		return "", err
+	}
+	var all, matches []Candidate
+	for _, s := range spaces {
+		folders, err := r.listFolders(ctx, s.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, f := range folders {
+			c := Candidate{ID: f.ID, Name: f.Name, Path: s.Name + "/" + f.Name}
+			all = append(all, c)
+			if matchPath(ref, f.Name, s.Name, f.Name) {
+				matches = append(matches, c)
+			}
+		}
+	}
+	return pick("folder", ref, matches, all)
+}
+
+// List returns the ID of the list named ref, in a folder or directly in a
+// space. ref may be a path such as "Engineering/Backend/Sprint 42" or
+// "Backend/Sprint 42". The search covers spaceID (an ID or name) or, when it
+// is empty, every space in the workspace.
+func (r *Resolver) List(ctx context.Context, ref, spaceID string) (string, error) {
+	if ref == "" || IsID(ref) {
+		return ref, nil
+	}
+	spaces, err := r.scope(ctx, spaceID)
+	if err != nil {
+		return "", err
+	}
+	var all, matches []Candidate
+	add := func(l api.List, path ...string) {
+		c := Candidate{ID: l.ID, Name: l.Name, Path: strings.Join(path, "/")}
+		all = append(all, c)
+		if matchPath(ref, l.Name, path...) {
+			matches = append(matches, c)
+		}
+	}
+	for _, s := range spaces {
+		folders, err := r.listFolders(ctx, s.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, f := range folders {
+			lists := f.Lists
+			if lists == nil {
+				resp, err := r.Client.ListLists(ctx, f.ID)
+				if err != nil {
+					return "", err
+				}
+				lists = resp.Lists
+			}
+			for _, l := range lists {
+				add(l, s.Name, f.Name, l.Name)
+			}
+		}
+		lists, err := r.listFolderless(ctx, s.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, l := range lists {
+			add(l, s.Name, l.Name)
+		}
+	}
+	return pick("list", ref, matches, all)
+}
+
+// User returns the ID of the user ref refers to: a numeric ID, "me", an
+// email address or a username. Users are looked up among the members of
+// listID, or of the workspace when listID is empty.
+func (r *Resolver) User(ctx context.Context, ref, listID string) (int, error) {
+	if IsID(ref) {
+		return strconv.Atoi(ref)
+	}
+	if strings.EqualFold(ref, "me") {
+		resp, err := r.Client.GetUser(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return resp.User.ID, nil
+	}
+
+	members, err := r.members(ctx, listID)
+	if err != nil {
+		return 0, err
+	}
+	field := func(m api.Member) string { return m.Username }
+	if strings.Contains(ref, "@") {
+		field = func(m api.Member) string { return m.Email }
+	}
+	var all, matches []Candidate
+	ids := map[string]int{}
+	for _, m := range members {
+		id := strconv.Itoa(m.ID)
+		c := Candidate{ID: id, Name: m.Username, Email: m.Email}
+		ids[id] = m.ID
+		all = append(all, c)
+		if strings.EqualFold(field(m), ref) {
+			matches = append(matches, c)
+		}
+	}
+	id, err := pick("user", ref, matches, all)
+	if err != nil {
+		return 0, err
+	}
+	return ids[id], nil
+}
+
+// Users resolves each of refs with User.
+func (r *Resolver) Users(ctx context.Context, refs []string, listID string) ([]int, error) {
+	var ids []int
+	for _, ref := range refs {
+		id, err := r.User(ctx, ref, listID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Status returns the status of listID whose name matches ref ignoring case,
+// spelled the way the list spells it.
+func (r *Resolver) Status(ctx context.Context, ref, listID string) (string, error) {
+	if ref == "" || listID == "" {
+		return ref, nil
+	}
+	statuses, err := r.listStatuses(ctx, listID)
+	if err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return ref, nil
+	}
+	var all []Candidate
+	for _, s := range statuses {
+		if strings.EqualFold(s.Status, ref) {
+			return s.Status, nil
+		}
+		all = append(all, Candidate{Name: s.Status})
+	}
+	return "", &Error{Kind: "status", Ref: ref, Candidates: all}
+}
+
+// Statuses resolves each of refs with Status.
+func (r *Resolver) Statuses(ctx context.Context, refs []string, listID string) ([]string, error) {
+	var names []string
+	for _, ref := range refs {
+		name, err := r.Status(ctx, ref, listID)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// maxListed caps how many choices a not-found error lists.
+const maxListed = 20
+
+// pick returns the one match, or an Error naming the matches or choices.
+func pick(kind, ref string, matches, all []Candidate) (string, error) {
+	switch len(matches) {
+	case 1:
+		return matches[0].ID, nil
+	case 0:
+		if len(all) > maxListed {
+			all = nil
+		}
+		return "", &Error{Kind: kind, Ref: ref, Candidates: all}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Path < matches[j].Path })
+	return "", &Error{Kind: kind, Ref: ref, Ambiguous: true, Candidates: matches}
+}
+
+// matchPath reports whether ref names an object called name at path: either
+// the whole name, or the trailing segments of path separated by "/".
+func matchPath(ref, name string, path ...string) bool {
+	if strings.EqualFold(ref, name) {
+		return true
+	}
+	segs := strings.Split(ref, "/")
+	if len(segs) > len(path) {
+		return false
+	}
+	tail := path[len(path)-len(segs):]
+	for i, s := range segs {
+		if !strings.EqualFold(strings.TrimSpace(s), tail[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// scope returns the spaces to search: the one spaceID names, or all.
+func (r *Resolver) scope(ctx context.Context, spaceID string) ([]api.Space, error) {
+	spaces, err := r.listSpaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if spaceID == "" {
+		return spaces, nil
+	}
+	id, err := r.Space(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range spaces {
+		if s.ID == id {
+			return []api.Space{s}, nil
+		}
+	}
+	return []api.Space{{ID: id, Name: id}}, nil
+}
+
+func (r *Resolver) listSpaces(ctx context.Context) ([]api.Space, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.spaces != nil {
+		return r.spaces, nil
+	}
+	if r.WorkspaceID == "" {
+		return nil, fmt.Errorf("a workspace is required to look up names; use --workspace or 'clickup config set workspace <id>'")
+	}
+	resp, err := r.Client.ListSpaces(ctx, r.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	r.spaces = resp.Spaces
+	return r.spaces, nil
+}
+
+func (r *Resolver) listFolders(ctx context.Context, spaceID string) ([]api.Folder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if folders, ok := r.folders[spaceID]; ok {
+		return folders, nil
+	}
+	resp, err := r.Client.ListFolders(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if r.folders == nil {
+		r.folders = map[string][]api.Folder{}
+	}
+	r.folders[spaceID] = resp.Folders
+	return resp.Folders, nil
+}
+
+func (r *Resolver) listFolderless(ctx context.Context, spaceID string) ([]api.List, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lists, ok := r.folderless[spaceID]; ok {
+		return lists, nil
+	}
+	resp, err := r.Client.ListFolderlessLists(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if r.folderless == nil {
+		r.folderless = map[string][]api.List{}
+	}
+	r.folderless[spaceID] = resp.Lists
+	return resp.Lists, nil
+}
+
+func (r *Resolver) listStatuses(ctx context.Context, listID string) ([]api.TaskStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if statuses, ok := r.statuses[listID]; ok {
+		return statuses, nil
+	}
+	list, err := r.Client.GetList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if r.statuses == nil {
+		r.statuses = map[string][]api.TaskStatus{}
+	}
+	r.statuses[listID] = list.Statuses
+	return list.Statuses, nil
+}
+
+func (r *Resolver) members(ctx context.Context, listID string) ([]api.Member, error) {
+	if listID != "" {
+		resp, err := r.Client.GetListMembers(ctx, listID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Members, nil
+	}
+	resp, err := r.Client.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range resp.Teams {
+		if w.ID != r.WorkspaceID && r.WorkspaceID != "" {
+			continue
+		}
+		var members []api.Member
+		for _, m := range w.Members {
+			members = append(members, api.Member{ID: m.User.ID, Username: m.User.Username, Email: m.User.Email})
+		}
+		return members, nil
+	}
+	return nil, fmt.Errorf("workspace %s not found", r.WorkspaceID)
+}
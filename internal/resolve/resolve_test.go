@@ -0,0 +1,207 @@
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/testutil"
+)
+
+func hierarchy() *testutil.MockClient {
+	list := func(id, name string) api.List { return api.List{ID: id, Name: name} }
+	return &testutil.MockClient{
+		ListSpacesFn: func(_ context.Context, wid string) (*api.SpacesResponse, error) {
+			return &api.SpacesResponse{Spaces: []api.Space{{ID: "1", Name: "Engineering"}, {ID: "2", Name: "Marketing"}}}, nil
+		},
+		ListFoldersFn: func(_ context.Context, spaceID string, _ ...api.ListFoldersOptions) (*api.FoldersResponse, error) {
+			switch spaceID {
+			case "1":
+				return &api.FoldersResponse{Folders: []api.Folder{
+					{ID: "10", Name: "Backend", Lists: []api.List{list("100", "Sprint 42"), list("101", "Bugs")}},
+					{ID: "11", Name: "Frontend", Lists: []api.List{list("110", "Sprint 42")}},
+				}}, nil
+			default:
+				return &api.FoldersResponse{Folders: []api.Folder{{ID: "20", Name: "Campaigns"}}}, nil
+			}
+		},
+		ListListsFn: func(_ context.Context, folderID string, _ ...api.ListListsOptions) (*api.ListsResponse, error) {
+			return &api.ListsResponse{Lists: []api.List{list("200", "Launch")}}, nil
+		},
+		ListFolderlessListsFn: func(_ context.Context, spaceID string, _ ...api.ListListsOptions) (*api.ListsResponse, error) {
+			if spaceID == "2" {
+				return &api.ListsResponse{Lists: []api.List{list("210", "Bugs")}}, nil
+			}
+			return &api.ListsResponse{}, nil
+		},
+	}
+}
+
+func TestResolveHierarchy(t *testing.T) {
+	ctx := context.Background()
+	r := New(hierarchy(), "9")
+
+	tests := []struct {
+		name  string
+		fn    func() (string, error)
+		want  string
+		code  string
+		count int
+	}{
+		{"space by name", func() (string, error) { return r.Space(ctx, "engineering") }, "1", "", 0},
+		{"space id passes through", func() (string, error) { return r.Space(ctx, "12345") }, "12345", "", 0},
+		{"unknown space", func() (string, error) { return r.Space(ctx, "Sales") }, "", "NOT_FOUND", 2},
+		{"folder by name", func() (string, error) { return r.Folder(ctx, "frontend", "") }, "11", "", 0},
+		{"folder by path", func() (string, error) { return r.Folder(ctx, "Marketing/Campaigns", "") }, "20", "", 0},
+		{"list in folder fetched separately", func() (string, error) { return r.List(ctx, "Launch", "") }, "200", "", 0},
+		{"ambiguous list", func() (string, error) { return r.List(ctx, "Sprint 42", "") }, "", "AMBIGUOUS", 2},
+		{"list by path", func() (string, error) { return r.List(ctx, "Backend/Sprint 42", "") }, "100", "", 0},
+		{"list by full path", func() (string, error) { return r.List(ctx, "engineering/frontend/sprint 42", "") }, "110", "", 0},
+		{"folderless list", func() (string, error) { return r.List(ctx, "Marketing/Bugs", "") }, "210", "", 0},
+		{"list scoped to a space", func() (string, error) { return r.List(ctx, "Bugs", "Engineering") }, "101", "", 0},
+		{"ambiguous across spaces", func() (string, error) { return r.List(ctx, "Bugs", "") }, "", "AMBIGUOUS", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if tt.code == "" {
+				if err != nil || got != tt.want {
+					t.Fatalf("got %q, %v; want %q", got, err, tt.want)
+				}
+				return
+			}
+			var re *Error
+			if !errors.As(err, &re) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if re.Code() != tt.code || len(re.Candidates) != tt.count {
+				t.Errorf("got %s with %d candidates %+v", re.Code(), len(re.Candidates), re.Candidates)
+			}
+		})
+	}
+}
+
+func TestResolveAmbiguousCandidates(t *testing.T) {
+	_, err := New(hierarchy(), "9").List(context.Background(), "Sprint 42", "")
+	var re *Error
+	if !errors.As(err, &re) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	want := []Candidate{
+		{ID: "100", Name: "Sprint 42", Path: "Engineering/Backend/Sprint 42"},
+		{ID: "110", Name: "Sprint 42", Path: "Engineering/Frontend/Sprint 42"},
+	}
+	for i, c := range re.Candidates {
+		if c != want[i] {
+			t.Errorf("candidate %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+func TestResolveMemoizes(t *testing.T) {
+	mock := hierarchy()
+	calls := 0
+	listSpaces := mock.ListSpacesFn
+	mock.ListSpacesFn = func(ctx context.Context, wid string) (*api.SpacesResponse, error) {
+		calls++
+		return listSpaces(ctx, wid)
+	}
+	r := New(mock, "9")
+	_, _ = r.Space(context.Background(), "Engineering")
+	_, _ = r.List(context.Background(), "Launch", "")
+	if calls != 1 {
+		t.Errorf("expected spaces to be fetched once, got %d", calls)
+	}
+}
+
+func TestResolveWorkspaceRequired(t *testing.T) {
+	if _, err := New(hierarchy(), "").Space(context.Background(), "Engineering"); err == nil {
+		t.Error("expected an error without a workspace")
+	}
+}
+
+func TestResolveUser(t *testing.T) {
+	ctx := context.Background()
+	var workspaces api.WorkspacesResponse
+	if err := json.Unmarshal([]byte(`{"teams":[{"id":"9","members":[
+		{"user":{"id":1,"username":"alice","email":"alice@corp.com"}},
+		{"user":{"id":2,"username":"bob","email":"bob@corp.com"}},
+		{"user":{"id":3,"username":"bob","email":"robert@corp.com"}}]}]}`), &workspaces); err != nil {
+		t.Fatal(err)
+	}
+	mock := &testutil.MockClient{
+		ListWorkspacesFn: func(context.Context) (*api.WorkspacesResponse, error) { return &workspaces, nil },
+		GetUserFn: func(context.Context) (*api.UserResponse, error) {
+			return &api.UserResponse{User: api.User{ID: 42}}, nil
+		},
+		GetListMembersFn: func(_ context.Context, listID string) (*api.MembersResponse, error) {
+			return &api.MembersResponse{Members: []api.Member{{ID: 7, Username: "carol", Email: "carol@corp.com"}}}, nil
+		},
+	}
+	r := New(mock, "9")
+
+	for ref, want := range map[string]int{"123": 123, "me": 42, "ALICE@corp.com": 1, "alice": 1, "robert@corp.com": 3} {
+		if got, err := r.User(ctx, ref, ""); err != nil || got != want {
+			t.Errorf("%s: got %d, %v; want %d", ref, got, err, want)
+		}
+	}
+	if got, err := r.User(ctx, "carol", "100"); err != nil || got != 7 {
+		t.Errorf("list member: got %d, %v", got, err)
+	}
+
+	var re *Error
+	if _, err := r.User(ctx, "bob", ""); !errors.As(err, &re) || !re.Ambiguous || len(re.Candidates) != 2 {
+		t.Errorf("expected ambiguous bob, got %v", err)
+	}
+	if _, err := r.User(ctx, "dave@corp.com", ""); !errors.As(err, &re) || re.Ambiguous || len(re.Candidates) != 3 {
+		t.Errorf("expected not found with 3 candidates, got %v", err)
+	}
+
+	ids, err := r.Users(ctx, []string{"alice", "me"}, "")
+	if err != nil || len(ids) != 2 || ids[0] != 1 || ids[1] != 42 {
+		t.Errorf("Users = %v, %v", ids, err)
+	}
+}
+
+func TestResolveStatus(t *testing.T) {
+	calls := 0
+	mock := &testutil.MockClient{
+		GetListFn: func(_ context.Context, id string) (*api.List, error) {
+			calls++
+			return &api.List{ID: id, Statuses: []api.TaskStatus{{Status: "to do"}, {Status: "In Review"}}}, nil
+		},
+	}
+	r := New(mock, "9")
+	if got, err := r.Status(context.Background(), "in review", "100"); err != nil || got != "In Review" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	got, err := r.Statuses(context.Background(), []string{"TO DO", "In Review"}, "100")
+	if err != nil || len(got) != 2 || got[0] != "to do" {
+		t.Errorf("Statuses = %v, %v", got, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected one GetList call, got %d", calls)
+	}
+
+	var re *Error
+	if _, err := r.Status(context.Background(), "done", "100"); !errors.As(err, &re) || len(re.Candidates) != 2 {
+		t.Errorf("expected not found with the list's statuses, got %v", err)
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	path := []string{"Engineering", "Backend", "Sprint 42"}
+	for ref, want := range map[string]bool{
+		"Sprint 42":                          true,
+		"backend/sprint 42":                  true,
+		"Engineering / Backend / Sprint 42":  true,
+		"Frontend/Sprint 42":                 false,
+		"Acme/Engineering/Backend/Sprint 42": false,
+	} {
+		if got := matchPath(ref, "Sprint 42", path...); got != want {
+			t.Errorf("matchPath(%q) = %v, want %v", ref, got, want)
+		}
+	}
+}
@@ -9,19 +9,20 @@ import (
 // MockClient implements api.ClientInterface for testing.
 type MockClient struct {
 	GetUserFn              func(context.Context) (*api.UserResponse, error)
+	GetAccessTokenFn       func(context.Context, *api.AccessTokenRequest) (*api.AccessTokenResponse, error)
 	ListWorkspacesFn       func(context.Context) (*api.WorkspacesResponse, error)
 	ListSpacesFn           func(context.Context, string) (*api.SpacesResponse, error)
 	GetSpaceFn             func(context.Context, string) (*api.Space, error)
 	CreateSpaceFn          func(context.Context, string, *api.CreateSpaceRequest) (*api.Space, error)
 	UpdateSpaceFn          func(context.Context, string, *api.UpdateSpaceRequest) (*api.Space, error)
 	DeleteSpaceFn          func(context.Context, string) error
-	ListFoldersFn          func(context.Context, string) (*api.FoldersResponse, error)
+	ListFoldersFn          func(context.Context, string, ...api.ListFoldersOptions) (*api.FoldersResponse, error)
 	GetFolderFn            func(context.Context, string) (*api.Folder, error)
 	CreateFolderFn         func(context.Context, string, *api.CreateFolderRequest) (*api.Folder, error)
 	UpdateFolderFn         func(context.Context, string, *api.UpdateFolderRequest) (*api.Folder, error)
 	DeleteFolderFn         func(context.Context, string) error
-	ListListsFn            func(context.Context, string) (*api.ListsResponse, error)
-	ListFolderlessListsFn  func(context.Context, string) (*api.ListsResponse, error)
+	ListListsFn            func(context.Context, string, ...api.ListListsOptions) (*api.ListsResponse, error)
+	ListFolderlessListsFn  func(context.Context, string, ...api.ListListsOptions) (*api.ListsResponse, error)
 	GetListFn              func(context.Context, string) (*api.List, error)
 	CreateListFn           func(context.Context, string, *api.CreateListRequest) (*api.List, error)
 	CreateFolderlessListFn func(context.Context, string, *api.CreateListRequest) (*api.List, error)
@@ -66,7 +67,7 @@ type MockClient struct {
 
 	// Docs
 	CreateDocFn         func(context.Context, string, *api.CreateDocRequest) (*api.Doc, error)
-	SearchDocsFn        func(context.Context, string) (*api.DocsResponse, error)
+	SearchDocsFn        func(context.Context, string, ...api.SearchDocsOptions) (*api.DocsResponse, error)
 	GetDocFn            func(context.Context, string, string) (*api.Doc, error)
 	CreatePageFn        func(context.Context, string, string, *api.CreatePageRequest) (*api.DocPage, error)
 	GetPageFn           func(context.Context, string, string, string) (*api.DocPage, error)
@@ -202,6 +203,9 @@ var _ api.ClientInterface = (*MockClient)(nil)
 func (m *MockClient) GetUser(ctx context.Context) (*api.UserResponse, error) {
 	return m.GetUserFn(ctx)
 }
+func (m *MockClient) GetAccessToken(ctx context.Context, req *api.AccessTokenRequest) (*api.AccessTokenResponse, error) {
+	return m.GetAccessTokenFn(ctx, req)
+}
 func (m *MockClient) ListWorkspaces(ctx context.Context) (*api.WorkspacesResponse, error) {
 	return m.ListWorkspacesFn(ctx)
 }
@@ -220,8 +224,8 @@ func (m *MockClient) UpdateSpace(ctx context.Context, id string, req *api.Update
 func (m *MockClient) DeleteSpace(ctx context.Context, id string) error {
 	return m.DeleteSpaceFn(ctx, id)
 }
-func (m *MockClient) ListFolders(ctx context.Context, id string) (*api.FoldersResponse, error) {
-	return m.ListFoldersFn(ctx, id)
+func (m *MockClient) ListFolders(ctx context.Context, id string, opts ...api.ListFoldersOptions) (*api.FoldersResponse, error) {
+	return m.ListFoldersFn(ctx, id, opts...)
 }
 func (m *MockClient) GetFolder(ctx context.Context, id string) (*api.Folder, error) {
 	return m.GetFolderFn(ctx, id)
@@ -235,11 +239,11 @@ func (m *MockClient) UpdateFolder(ctx context.Context, id string, req *api.Updat
 func (m *MockClient) DeleteFolder(ctx context.Context, id string) error {
 	return m.DeleteFolderFn(ctx, id)
 }
-func (m *MockClient) ListLists(ctx context.Context, id string) (*api.ListsResponse, error) {
-	return m.ListListsFn(ctx, id)
+func (m *MockClient) ListLists(ctx context.Context, id string, opts ...api.ListListsOptions) (*api.ListsResponse, error) {
+	return m.ListListsFn(ctx, id, opts...)
 }
-func (m *MockClient) ListFolderlessLists(ctx context.Context, id string) (*api.ListsResponse, error) {
-	return m.ListFolderlessListsFn(ctx, id)
+func (m *MockClient) ListFolderlessLists(ctx context.Context, id string, opts ...api.ListListsOptions) (*api.ListsResponse, error) {
+	return m.ListFolderlessListsFn(ctx, id, opts...)
 }
 func (m *MockClient) GetList(ctx context.Context, id string) (*api.List, error) {
 	return m.GetListFn(ctx, id)
@@ -357,8 +361,8 @@ func (m *MockClient) DeleteChecklistItem(ctx context.Context, cid, iid string) e
 func (m *MockClient) CreateDoc(ctx context.Context, wid string, req *api.CreateDocRequest) (*api.Doc, error) {
 	return m.CreateDocFn(ctx, wid, req)
 }
-func (m *MockClient) SearchDocs(ctx context.Context, wid string) (*api.DocsResponse, error) {
-	return m.SearchDocsFn(ctx, wid)
+func (m *MockClient) SearchDocs(ctx context.Context, wid string, opts ...api.SearchDocsOptions) (*api.DocsResponse, error) {
+	return m.SearchDocsFn(ctx, wid, opts...)
 }
 func (m *MockClient) GetDoc(ctx context.Context, wid, did string) (*api.Doc, error) {
 	return m.GetDocFn(ctx, wid, did)
@@ -0,0 +1,264 @@
+// Package tree walks a workspace's spaces, folders and lists into a single
+// nested structure.
+package tree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/blockful/clickup-cli/internal/api"
+)
+
+// Node types.
+const (
+	TypeWorkspace = "workspace"
+	TypeSpace     = "space"
+	TypeFolder    = "folder"
+	TypeList      = "list"
+)
+
+// DefaultConcurrency is how many requests Walk makes at once by default.
+const DefaultConcurrency = 8
+
+// Node is a workspace, space, folder or list and what it contains: spaces
+// under the workspace, folders then folderless lists under a space, lists
+// under a folder.
+type Node struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	TaskCount *int    `json:"task_count,omitempty"`
+	Children  []*Node `json:"children,omitempty"`
+
+	// folders and lists are a space's children, filled concurrently and
+	// joined into Children once the walk is done.
+	folders []*Node
+	lists   []*Node
+}
+
+// Options controls a walk.
+type Options struct {
+	// SpaceID limits the walk to one space.
+	SpaceID string
+	// Depth is how many levels below the workspace to include: 1 for
+	// spaces, 2 for folders and folderless lists, 3 for lists in folders.
+	// 0 includes everything.
+	Depth int
+	// TaskCounts adds the task count ClickUp reports for each list and
+	// folder, summed up to spaces and the workspace.
+	TaskCounts bool
+	// Concurrency caps the requests in flight; 0 means DefaultConcurrency.
+	Concurrency int
+}
+
+// Walk fetches the hierarchy of workspaceID. Spaces, and folders within a
+// space, are fetched concurrently; the first error stops the walk.
+func Walk(ctx context.Context, client api.ClientInterface, workspaceID string, opts Options) (*Node, error) {
+	if opts.Depth <= 0 || opts.Depth > 3 {
+		opts.Depth = 3
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := &walker{client: client, opts: opts, ctx: ctx, cancel: cancel, sem: make(chan struct{}, opts.Concurrency)}
+
+	root := &Node{ID: workspaceID, Name: workspaceID, Type: TypeWorkspace}
+	w.spawn(func(ctx context.Context) error {
+		resp, err := client.ListWorkspaces(ctx)
+		if err != nil {
+			return err
+		}
+		for _, ws := range resp.Teams {
+			if ws.ID == workspaceID {
+				root.Name = ws.Name
+			}
+		}
+		return nil
+	})
+	w.spawn(func(ctx context.Context) error {
+		resp, err := client.ListSpaces(ctx, workspaceID)
+		if err != nil {
+			return err
+		}
+		for _, s := range resp.Spaces {
+			if opts.SpaceID != "" && s.ID != opts.SpaceID {
+				continue
+			}
+			node := &Node{ID: s.ID, Name: s.Name, Type: TypeSpace}
+			root.Children = append(root.Children, node)
+			if opts.Depth >= 2 || opts.TaskCounts {
+				w.space(node)
+			}
+		}
+		if opts.SpaceID != "" && len(root.Children) == 0 {
+			return fmt.Errorf("space %s not found in workspace %s", opts.SpaceID, workspaceID)
+		}
+		return nil
+	})
+	if err := w.wait(); err != nil {
+		return nil, err
+	}
+	root.finish(opts, 0)
+	return root, nil
+}
+
+type walker struct {
+	client api.ClientInterface
+	opts   Options
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// spawn runs fn in a goroutine once a request slot is free. fn may spawn
+// further work; a slot is never held while waiting for another.
+func (w *walker) spawn(fn func(ctx context.Context) error) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		select {
+		case w.sem <- struct{}{}:
+		case <-w.ctx.Done():
+			return
+		}
+		defer func() { <-w.sem }()
+		if err := fn(w.ctx); err != nil {
+			w.mu.Lock()
+			if w.err == nil {
+				w.err = err
+				w.cancel()
+			}
+			w.mu.Unlock()
+		}
+	}()
+}
+
+func (w *walker) wait() error {
+	w.wg.Wait()
+	return w.err
+}
+
+// space fetches the folders and folderless lists of a space node.
+func (w *walker) space(node *Node) {
+	w.spawn(func(ctx context.Context) error {
+		resp, err := w.client.ListFolders(ctx, node.ID)
+		if err != nil {
+			return err
+		}
+		node.folders = make([]*Node, len(resp.Folders))
+		for i, f := range resp.Folders {
+			folder := &Node{ID: f.ID, Name: f.Name, Type: TypeFolder}
+			if n, err := strconv.Atoi(f.TaskCount); err == nil {
+				folder.TaskCount = &n
+			}
+			node.folders[i] = folder
+			if w.opts.Depth < 3 {
+				continue
+			}
+			if f.Lists != nil {
+				folder.Children = listNodes(f.Lists)
+				continue
+			}
+			w.spawn(func(ctx context.Context) error {
+				resp, err := w.client.ListLists(ctx, folder.ID)
+				if err != nil {
+					return err
+				}
+				folder.Children = listNodes(resp.Lists)
+				return nil
+			})
+		}
+		return nil
+	})
+	w.spawn(func(ctx context.Context) error {
+		resp, err := w.client.ListFolderlessLists(ctx, node.ID)
+		if err != nil {
+			return err
+		}
+		node.lists = listNodes(resp.Lists)
+		return nil
+	})
+}
+
+func listNodes(lists []api.List) []*Node {
+	nodes := make([]*Node, len(lists))
+	for i, l := range lists {
+		n := l.TaskCount
+		nodes[i] = &Node{ID: l.ID, Name: l.Name, Type: TypeList, TaskCount: &n}
+	}
+	return nodes
+}
+
+// finish joins a space's folders and lists into its children, sums task
+// counts, and drops what lies deeper than opts.Depth. level is n's depth
+// below the workspace.
+func (n *Node) finish(opts Options, level int) {
+	if n.Type == TypeSpace {
+		n.Children = append(n.folders, n.lists...)
+	}
+	for _, c := range n.Children {
+		c.finish(opts, level+1)
+	}
+	if !opts.TaskCounts {
+		n.TaskCount = nil
+	} else if n.Type == TypeWorkspace || n.Type == TypeSpace || n.TaskCount == nil {
+		sum := 0
+		for _, c := range n.Children {
+			if c.TaskCount != nil {
+				sum += *c.TaskCount
+			}
+		}
+		n.TaskCount = &sum
+	}
+	if level >= opts.Depth {
+		n.Children = nil
+	}
+}
+
+// WriteText prints the tree with box-drawing branches, one node per line.
+func (n *Node) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, n.label()); err != nil {
+		return err
+	}
+	return n.writeChildren(w, "")
+}
+
+func (n *Node) writeChildren(w io.Writer, prefix string) error {
+	for i, c := range n.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(n.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if _, err := fmt.Fprintln(w, prefix+branch+c.label()); err != nil {
+			return err
+		}
+		if err := c.writeChildren(w, prefix+indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Node) label() string {
+	name := n.Name
+	if n.Type == TypeFolder {
+		name += "/"
+	}
+	s := fmt.Sprintf("%s (%s %s", name, n.Type, n.ID)
+	if n.TaskCount != nil {
+		s += fmt.Sprintf(", %d task", *n.TaskCount)
+		if *n.TaskCount != 1 {
+			s += "s"
+		}
+	}
+	return s + ")"
+}
@@ -0,0 +1,129 @@
+package tree
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/blockful/clickup-cli/internal/api"
+	"github.com/blockful/clickup-cli/internal/testutil"
+)
+
+func hierarchy() *testutil.MockClient {
+	list := func(id, name string, tasks int) api.List { return api.List{ID: id, Name: name, TaskCount: tasks} }
+	return &testutil.MockClient{
+		ListWorkspacesFn: func(context.Context) (*api.WorkspacesResponse, error) {
+			return &api.WorkspacesResponse{Teams: []api.Workspace{{ID: "9", Name: "Acme"}}}, nil
+		},
+		ListSpacesFn: func(context.Context, string) (*api.SpacesResponse, error) {
+			return &api.SpacesResponse{Spaces: []api.Space{{ID: "1", Name: "Engineering"}, {ID: "2", Name: "Marketing"}}}, nil
+		},
+		ListFoldersFn: func(_ context.Context, spaceID string, _ ...api.ListFoldersOptions) (*api.FoldersResponse, error) {
+			if spaceID == "2" {
+				return &api.FoldersResponse{}, nil
+			}
+			return &api.FoldersResponse{Folders: []api.Folder{
+				{ID: "10", Name: "Backend", TaskCount: "5", Lists: []api.List{list("100", "Sprint 42", 3), list("101", "Bugs", 2)}},
+				{ID: "11", Name: "Frontend", TaskCount: "4"},
+			}}, nil
+		},
+		ListListsFn: func(_ context.Context, folderID string, _ ...api.ListListsOptions) (*api.ListsResponse, error) {
+			return &api.ListsResponse{Lists: []api.List{list("110", "Sprint 42", 4)}}, nil
+		},
+		ListFolderlessListsFn: func(_ context.Context, spaceID string, _ ...api.ListListsOptions) (*api.ListsResponse, error) {
+			if spaceID == "2" {
+				return &api.ListsResponse{Lists: []api.List{list("200", "Launch", 7)}}, nil
+			}
+			return &api.ListsResponse{Lists: []api.List{list("120", "Inbox", 1)}}, nil
+		},
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWalk(t *testing.T) {
+	root, err := Walk(context.Background(), hierarchy(), "9", Options{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `{"id":"9","name":"Acme","type":"workspace","children":[
+		{"id":"1","name":"Engineering","type":"space","children":[
+			{"id":"10","name":"Backend","type":"folder","children":[
+				{"id":"100","name":"Sprint 42","type":"list"},
+				{"id":"101","name":"Bugs","type":"list"}]},
+			{"id":"11","name":"Frontend","type":"folder","children":[
+				{"id":"110","name":"Sprint 42","type":"list"}]},
+			{"id":"120","name":"Inbox","type":"list"}]},
+		{"id":"2","name":"Marketing","type":"space","children":[
+			{"id":"200","name":"Launch","type":"list"}]}]}`, mustJSON(t, root))
+}
+
+func TestWalkTaskCountsAndDepth(t *testing.T) {
+	root, err := Walk(context.Background(), hierarchy(), "9", Options{Depth: 2, TaskCounts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `{"id":"9","name":"Acme","type":"workspace","task_count":17,"children":[
+		{"id":"1","name":"Engineering","type":"space","task_count":10,"children":[
+			{"id":"10","name":"Backend","type":"folder","task_count":5},
+			{"id":"11","name":"Frontend","type":"folder","task_count":4},
+			{"id":"120","name":"Inbox","type":"list","task_count":1}]},
+		{"id":"2","name":"Marketing","type":"space","task_count":7,"children":[
+			{"id":"200","name":"Launch","type":"list","task_count":7}]}]}`, mustJSON(t, root))
+}
+
+func TestWalkSpace(t *testing.T) {
+	root, err := Walk(context.Background(), hierarchy(), "9", Options{SpaceID: "2", Depth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertJSONEqual(t, `{"id":"9","name":"Acme","type":"workspace","children":[
+		{"id":"2","name":"Marketing","type":"space"}]}`, mustJSON(t, root))
+
+	if _, err := Walk(context.Background(), hierarchy(), "9", Options{SpaceID: "3"}); err == nil {
+		t.Error("expected an error for a space outside the workspace")
+	}
+}
+
+func TestWalkError(t *testing.T) {
+	mock := hierarchy()
+	boom := errors.New("boom")
+	mock.ListListsFn = func(context.Context, string, ...api.ListListsOptions) (*api.ListsResponse, error) { return nil, boom }
+	if _, err := Walk(context.Background(), mock, "9", Options{}); !errors.Is(err, boom) {
+		t.Errorf("expected the failing call's error, got %v", err)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	root, err := Walk(context.Background(), hierarchy(), "9", Options{TaskCounts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := root.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `Acme (workspace 9, 17 tasks)
+├── Engineering (space 1, 10 tasks)
+│   ├── Backend/ (folder 10, 5 tasks)
+│   │   ├── Sprint 42 (list 100, 3 tasks)
+│   │   └── Bugs (list 101, 2 tasks)
+│   ├── Frontend/ (folder 11, 4 tasks)
+│   │   └── Sprint 42 (list 110, 4 tasks)
+│   └── Inbox (list 120, 1 task)
+└── Marketing (space 2, 7 tasks)
+    └── Launch (list 200, 7 tasks)
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
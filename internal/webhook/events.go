@@ -0,0 +1,403 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// History item fields that the typed task events read.
+const (
+	FieldTaskCreation = "task_creation"
+	FieldStatus       = "status"
+	FieldPriority     = "priority"
+	FieldAssigneeAdd  = "assignee_add"
+	FieldAssigneeRem  = "assignee_rem"
+	FieldDueDate      = "due_date"
+	FieldTag          = "tag"
+	FieldTagRemoved   = "tag_removed"
+	FieldSectionMoved = "section_moved"
+	FieldComment      = "comment"
+	FieldTimeEstimate = "time_estimate"
+	FieldTimeSpent    = "time_spent"
+)
+
+// Status is a task status as it appears in taskStatusUpdated.
+type Status struct {
+	Status     string `json:"status"`
+	Color      string `json:"color"`
+	Type       string `json:"type"`
+	OrderIndex ID     `json:"orderindex"`
+}
+
+// Priority is a task priority as it appears in taskPriorityUpdated.
+type Priority struct {
+	ID         ID     `json:"id"`
+	Priority   string `json:"priority"`
+	Color      string `json:"color"`
+	OrderIndex ID     `json:"orderindex"`
+}
+
+// Tag is a task tag as it appears in taskTagUpdated.
+type Tag struct {
+	Name    string `json:"name"`
+	TagFg   string `json:"tag_fg"`
+	TagBg   string `json:"tag_bg"`
+	Creator int    `json:"creator"`
+}
+
+// Location is where a task lives: its list, with the list's folder
+// (Category) and space (Project) in ClickUp's internal naming.
+type Location struct {
+	ID       ID         `json:"id"`
+	Name     string     `json:"name"`
+	Category *Container `json:"category,omitempty"`
+	Project  *Container `json:"project,omitempty"`
+}
+
+// Container is the folder or space of a Location.
+type Container struct {
+	ID     ID     `json:"id"`
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden,omitempty"`
+}
+
+// Comment is a comment as it appears in taskCommentPosted and
+// taskCommentUpdated. Blocks holds the rich-text content.
+type Comment struct {
+	ID          ID              `json:"id"`
+	Date        ID              `json:"date"`
+	Parent      ID              `json:"parent"`
+	Type        int             `json:"type"`
+	TextContent string          `json:"text_content"`
+	User        *User           `json:"user,omitempty"`
+	Blocks      json.RawMessage `json:"comment,omitempty"`
+}
+
+// TimeInterval is a tracked time entry as it appears in
+// taskTimeTrackedUpdated. Times are Unix milliseconds.
+type TimeInterval struct {
+	ID        ID     `json:"id"`
+	Start     ID     `json:"start"`
+	End       ID     `json:"end"`
+	Time      ID     `json:"time"`
+	Source    string `json:"source"`
+	DateAdded ID     `json:"date_added"`
+}
+
+// TaskCreatedEvent is a taskCreated delivery.
+type TaskCreatedEvent struct {
+	Event
+	Creator *User `json:"creator,omitempty"`
+}
+
+// TaskUpdatedEvent is a taskUpdated delivery. Fields names what changed;
+// the history items hold the values.
+type TaskUpdatedEvent struct {
+	Event
+	Fields []string `json:"fields"`
+}
+
+// TaskDeletedEvent is a taskDeleted delivery.
+type TaskDeletedEvent struct {
+	Event
+}
+
+// TaskStatusUpdatedEvent is a taskStatusUpdated delivery.
+type TaskStatusUpdatedEvent struct {
+	Event
+	Before *Status `json:"before"`
+	After  *Status `json:"after"`
+}
+
+// TaskPriorityUpdatedEvent is a taskPriorityUpdated delivery. Before or
+// After is nil when the task had or has no priority.
+type TaskPriorityUpdatedEvent struct {
+	Event
+	Before *Priority `json:"before"`
+	After  *Priority `json:"after"`
+}
+
+// TaskAssigneeUpdatedEvent is a taskAssigneeUpdated delivery.
+type TaskAssigneeUpdatedEvent struct {
+	Event
+	Added   []User `json:"added,omitempty"`
+	Removed []User `json:"removed,omitempty"`
+}
+
+// TaskDueDateUpdatedEvent is a taskDueDateUpdated delivery. Dates are Unix
+// milliseconds, empty when the task had or has no due date.
+type TaskDueDateUpdatedEvent struct {
+	Event
+	Before ID `json:"before"`
+	After  ID `json:"after"`
+}
+
+// TaskTagUpdatedEvent is a taskTagUpdated delivery.
+type TaskTagUpdatedEvent struct {
+	Event
+	Added   []Tag `json:"added,omitempty"`
+	Removed []Tag `json:"removed,omitempty"`
+}
+
+// TaskMovedEvent is a taskMoved delivery.
+type TaskMovedEvent struct {
+	Event
+	Before *Location `json:"before"`
+	After  *Location `json:"after"`
+}
+
+// TaskCommentEvent is a taskCommentPosted or taskCommentUpdated delivery.
+type TaskCommentEvent struct {
+	Event
+	Comment *Comment `json:"comment"`
+}
+
+// TaskTimeEstimateUpdatedEvent is a taskTimeEstimateUpdated delivery.
+// Estimates are milliseconds, empty when there was or is none.
+type TaskTimeEstimateUpdatedEvent struct {
+	Event
+	Before ID `json:"before"`
+	After  ID `json:"after"`
+}
+
+// TaskTimeTrackedUpdatedEvent is a taskTimeTrackedUpdated delivery. Before
+// is nil for new time and After for deleted time.
+type TaskTimeTrackedUpdatedEvent struct {
+	Event
+	Before *TimeInterval `json:"before"`
+	After  *TimeInterval `json:"after"`
+}
+
+// Change is what list, folder, space, goal and key result events add to the
+// envelope: who made the change and which fields its history items touch,
+// such as "name" or "content" for an update. Item and HistoryItem.Values
+// give the old and new values.
+type Change struct {
+	User   *User    `json:"user,omitempty"`
+	Fields []string `json:"fields"`
+}
+
+// ListEvent is a listCreated, listUpdated or listDeleted delivery. ListID
+// names the list.
+type ListEvent struct {
+	Event
+	Change
+}
+
+// FolderEvent is a folderCreated, folderUpdated or folderDeleted delivery.
+// FolderID names the folder.
+type FolderEvent struct {
+	Event
+	Change
+}
+
+// SpaceEvent is a spaceCreated, spaceUpdated or spaceDeleted delivery.
+// SpaceID names the space.
+type SpaceEvent struct {
+	Event
+	Change
+}
+
+// GoalEvent is a goalCreated, goalUpdated or goalDeleted delivery. GoalID
+// names the goal.
+type GoalEvent struct {
+	Event
+	Change
+}
+
+// KeyResultEvent is a keyResultCreated, keyResultUpdated or
+// keyResultDeleted delivery. KeyResultID names the key result and GoalID
+// its goal.
+type KeyResultEvent struct {
+	Event
+	Change
+}
+
+// Item returns the first history item for field, or nil.
+func (e *Event) Item(field string) *HistoryItem {
+	for i := range e.HistoryItems {
+		if e.HistoryItems[i].Field == field {
+			return &e.HistoryItems[i]
+		}
+	}
+	return nil
+}
+
+// Values decodes the item's Before and After into before and after. A
+// missing or null value leaves its target untouched; either target may be
+// nil to skip it.
+func (h *HistoryItem) Values(before, after interface{}) error {
+	if err := decodeValue(h.Before, before); err != nil {
+		return fmt.Errorf("%s before: %w", h.Field, err)
+	}
+	if err := decodeValue(h.After, after); err != nil {
+		return fmt.Errorf("%s after: %w", h.Field, err)
+	}
+	return nil
+}
+
+func decodeValue(raw json.RawMessage, v interface{}) error {
+	if v == nil || len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// parsers build the typed payload of each event.
+var parsers = map[string]func(*Event) (interface{}, error){
+	TaskCreated: func(e *Event) (interface{}, error) {
+		p := &TaskCreatedEvent{Event: *e}
+		if h := e.Item(FieldTaskCreation); h != nil {
+			p.Creator = h.User
+		}
+		return p, nil
+	},
+	TaskUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskUpdatedEvent{Event: *e, Fields: []string{}}
+		for _, h := range e.HistoryItems {
+			p.Fields = append(p.Fields, h.Field)
+		}
+		return p, nil
+	},
+	TaskDeleted: func(e *Event) (interface{}, error) {
+		return &TaskDeletedEvent{Event: *e}, nil
+	},
+	TaskStatusUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskStatusUpdatedEvent{Event: *e}
+		return p, values(e, FieldStatus, &p.Before, &p.After)
+	},
+	TaskPriorityUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskPriorityUpdatedEvent{Event: *e}
+		return p, values(e, FieldPriority, &p.Before, &p.After)
+	},
+	TaskAssigneeUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskAssigneeUpdatedEvent{Event: *e}
+		for _, h := range e.HistoryItems {
+			var u User
+			if err := h.Values(nil, &u); err != nil {
+				return nil, err
+			}
+			switch h.Field {
+			case FieldAssigneeAdd:
+				p.Added = append(p.Added, u)
+			case FieldAssigneeRem:
+				p.Removed = append(p.Removed, u)
+			}
+		}
+		return p, nil
+	},
+	TaskDueDateUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskDueDateUpdatedEvent{Event: *e}
+		return p, values(e, FieldDueDate, &p.Before, &p.After)
+	},
+	TaskTagUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskTagUpdatedEvent{Event: *e}
+		for _, h := range e.HistoryItems {
+			var tags []Tag
+			if err := h.Values(nil, &tags); err != nil {
+				return nil, err
+			}
+			switch h.Field {
+			case FieldTag:
+				p.Added = append(p.Added, tags...)
+			case FieldTagRemoved:
+				p.Removed = append(p.Removed, tags...)
+			}
+		}
+		return p, nil
+	},
+	TaskMoved: func(e *Event) (interface{}, error) {
+		p := &TaskMovedEvent{Event: *e}
+		return p, values(e, FieldSectionMoved, &p.Before, &p.After)
+	},
+	TaskCommentPosted:  parseComment,
+	TaskCommentUpdated: parseComment,
+	TaskTimeEstimateUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskTimeEstimateUpdatedEvent{Event: *e}
+		return p, values(e, FieldTimeEstimate, &p.Before, &p.After)
+	},
+	TaskTimeTrackedUpdated: func(e *Event) (interface{}, error) {
+		p := &TaskTimeTrackedUpdatedEvent{Event: *e}
+		return p, values(e, FieldTimeSpent, &p.Before, &p.After)
+	},
+	ListCreated:      parseList,
+	ListUpdated:      parseList,
+	ListDeleted:      parseList,
+	FolderCreated:    parseFolder,
+	FolderUpdated:    parseFolder,
+	FolderDeleted:    parseFolder,
+	SpaceCreated:     parseSpace,
+	SpaceUpdated:     parseSpace,
+	SpaceDeleted:     parseSpace,
+	GoalCreated:      parseGoal,
+	GoalUpdated:      parseGoal,
+	GoalDeleted:      parseGoal,
+	KeyResultCreated: parseKeyResult,
+	KeyResultUpdated: parseKeyResult,
+	KeyResultDeleted: parseKeyResult,
+}
+
+func parseList(e *Event) (interface{}, error) {
+	return &ListEvent{Event: *e, Change: change(e)}, nil
+}
+
+func parseFolder(e *Event) (interface{}, error) {
+	return &FolderEvent{Event: *e, Change: change(e)}, nil
+}
+
+func parseSpace(e *Event) (interface{}, error) {
+	return &SpaceEvent{Event: *e, Change: change(e)}, nil
+}
+
+func parseGoal(e *Event) (interface{}, error) {
+	return &GoalEvent{Event: *e, Change: change(e)}, nil
+}
+
+func parseKeyResult(e *Event) (interface{}, error) {
+	return &KeyResultEvent{Event: *e, Change: change(e)}, nil
+}
+
+// change collects the user and fields of e's history items.
+func change(e *Event) Change {
+	c := Change{Fields: []string{}}
+	for _, h := range e.HistoryItems {
+		if c.User == nil {
+			c.User = h.User
+		}
+		c.Fields = append(c.Fields, h.Field)
+	}
+	return c
+}
+
+func parseComment(e *Event) (interface{}, error) {
+	p := &TaskCommentEvent{Event: *e}
+	if h := e.Item(FieldComment); h != nil {
+		if err := decodeValue(h.Comment, &p.Comment); err != nil {
+			return nil, fmt.Errorf("comment: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// values decodes the Before and After of e's item for field, if it has one.
+func values(e *Event, field string, before, after interface{}) error {
+	if h := e.Item(field); h != nil {
+		return h.Values(before, after)
+	}
+	return nil
+}
+
+// Parse returns the typed payload of an event, such as a
+// *TaskStatusUpdatedEvent for taskStatusUpdated or a *ListEvent for
+// listUpdated. Unknown events are returned as e itself.
+func Parse(e *Event) (interface{}, error) {
+	parse, ok := parsers[e.Event]
+	if !ok {
+		return e, nil
+	}
+	p, err := parse(e)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.Event, err)
+	}
+	return p, nil
+}
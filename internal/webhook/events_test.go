@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fixture(t *testing.T, name string) *Event {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := Decode(data)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return e
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		event string
+		check func(t *testing.T, p interface{})
+	}{
+		{TaskCreated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskCreatedEvent)
+			if e.Creator == nil || e.Creator.Username != "John" || len(e.HistoryItems) != 2 {
+				t.Errorf("got %+v", e)
+			}
+		}},
+		{TaskUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskUpdatedEvent)
+			if strings.Join(e.Fields, ",") != "content,name" {
+				t.Errorf("got fields %v", e.Fields)
+			}
+		}},
+		{TaskDeleted, func(t *testing.T, p interface{}) {
+			if e := p.(*TaskDeletedEvent); e.TaskID != "1vj37mc" {
+				t.Errorf("got %+v", e)
+			}
+		}},
+		{TaskStatusUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskStatusUpdatedEvent)
+			if e.Before.Status != "to do" || e.After.Status != "in progress" || e.After.Type != "custom" || e.After.OrderIndex != "1" {
+				t.Errorf("got %+v -> %+v", e.Before, e.After)
+			}
+		}},
+		{TaskPriorityUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskPriorityUpdatedEvent)
+			if e.Before != nil || e.After == nil || e.After.Priority != "high" || e.After.ID != "2" {
+				t.Errorf("got %+v -> %+v", e.Before, e.After)
+			}
+		}},
+		{TaskAssigneeUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskAssigneeUpdatedEvent)
+			if len(e.Added) != 1 || e.Added[0].ID != 183 || len(e.Removed) != 1 || e.Removed[0].Username != "Jane" {
+				t.Errorf("got added %+v, removed %+v", e.Added, e.Removed)
+			}
+		}},
+		{TaskDueDateUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskDueDateUpdatedEvent)
+			if ms, err := e.After.Int64(); err != nil || ms != 1643004000000 || e.Before != "1642176000000" {
+				t.Errorf("got %q -> %q", e.Before, e.After)
+			}
+		}},
+		{TaskTagUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskTagUpdatedEvent)
+			if len(e.Added) != 1 || e.Added[0].Name != "shipped" || len(e.Removed) != 1 || e.Removed[0].Name != "blocked" {
+				t.Errorf("got added %+v, removed %+v", e.Added, e.Removed)
+			}
+		}},
+		{TaskMoved, func(t *testing.T, p interface{}) {
+			e := p.(*TaskMovedEvent)
+			if e.Before.Name != "Backlog" || e.After.ID != "162641285" || e.After.Category.Name != "Sprints" || e.After.Project.ID != "7002367" || e.ListID != "162641285" {
+				t.Errorf("got %+v -> %+v", e.Before, e.After)
+			}
+		}},
+		{TaskCommentPosted, func(t *testing.T, p interface{}) {
+			e := p.(*TaskCommentEvent)
+			if e.Comment == nil || e.Comment.ID != "648893191" || e.Comment.TextContent != "Looks good" || e.Comment.User.ID != 183 || len(e.Comment.Blocks) == 0 {
+				t.Errorf("got %+v", e.Comment)
+			}
+		}},
+		{TaskCommentUpdated, func(t *testing.T, p interface{}) {
+			if e := p.(*TaskCommentEvent); e.Comment == nil || e.Comment.TextContent != "Looks great" {
+				t.Errorf("got %+v", e.Comment)
+			}
+		}},
+		{TaskTimeEstimateUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskTimeEstimateUpdatedEvent)
+			if e.Before != "" || e.After != "5400000" {
+				t.Errorf("got %q -> %q", e.Before, e.After)
+			}
+		}},
+		{TaskTimeTrackedUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*TaskTimeTrackedUpdatedEvent)
+			if e.Before != nil || e.After == nil || e.After.Time != "900000" || e.After.Source != "clickup" {
+				t.Errorf("got %+v -> %+v", e.Before, e.After)
+			}
+		}},
+		{ListCreated, func(t *testing.T, p interface{}) {
+			if e := p.(*ListEvent); e.ListID != "162641543" || e.User != nil || len(e.Fields) != 0 {
+				t.Errorf("got %+v", e)
+			}
+		}},
+		{ListUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*ListEvent)
+			var before, after string
+			if err := e.Item("name").Values(&before, &after); err != nil {
+				t.Fatal(err)
+			}
+			if e.ListID != "162641285" || e.User.Username != "John" || strings.Join(e.Fields, ",") != "name" || after != "Webhook payloads round 2" {
+				t.Errorf("got %+v, name %q -> %q", e, before, after)
+			}
+		}},
+		{FolderCreated, func(t *testing.T, p interface{}) {
+			if e := p.(*FolderEvent); e.FolderID != "96772049" || e.User.ID != 183 {
+				t.Errorf("got %+v", e)
+			}
+		}},
+		{SpaceDeleted, func(t *testing.T, p interface{}) {
+			if e := p.(*SpaceEvent); e.SpaceID != "54650507" {
+				t.Errorf("got %+v", e)
+			}
+		}},
+		{GoalUpdated, func(t *testing.T, p interface{}) {
+			e := p.(*GoalEvent)
+			if e.GoalID == "" || e.HistoryItems[0].Date != "1642746271453" || strings.Join(e.Fields, ",") != "name" {
+				t.Errorf("got %+v", e)
+			}
+		}},
+		{KeyResultCreated, func(t *testing.T, p interface{}) {
+			e := p.(*KeyResultEvent)
+			if e.KeyResultID != "47608e42-ad0e-4934-a39e-950539c77e79" || e.GoalID == "" || e.User.Username != "John" {
+				t.Errorf("got %+v", e)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event, func(t *testing.T) {
+			e := fixture(t, tt.event)
+			if e.Event != tt.event {
+				t.Fatalf("fixture has event %q", e.Event)
+			}
+			p, err := Parse(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, p)
+		})
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	e := &Event{Event: "taskSomethingNew", TaskID: "1"}
+	if p, err := Parse(e); err != nil || p != e {
+		t.Errorf("expected an unknown event to be returned as is, got %v, %v", p, err)
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	e := &Event{Event: TaskStatusUpdated, HistoryItems: []HistoryItem{{Field: FieldStatus, After: []byte(`"done"`)}}}
+	if _, err := Parse(e); err == nil || !strings.Contains(err.Error(), "taskStatusUpdated: status after") {
+		t.Errorf("expected a status that is not an object to fail, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+)
+
+// Router dispatches events to handlers by event name. Its Dispatch method is
+// a HandlerFunc, so a Router can be passed to Handler:
+//
+//	r := webhook.NewRouter()
+//	r.OnTaskStatusUpdated(func(ctx context.Context, e *webhook.TaskStatusUpdatedEvent) error {
+//		log.Printf("%s: %s -> %s", e.TaskID, e.Before.Status, e.After.Status)
+//		return nil
+//	})
+//	http.Handle("/hooks", webhook.Handler(secret, r.Dispatch))
+type Router struct {
+	handlers map[string]HandlerFunc
+
+	// Fallback handles events without a handler. When nil they are
+	// acknowledged and dropped.
+	Fallback HandlerFunc
+}
+
+// NewRouter returns a Router without handlers.
+func NewRouter() *Router {
+	return &Router{handlers: map[string]HandlerFunc{}}
+}
+
+// Handle sets the handler for event, replacing any earlier one.
+func (r *Router) Handle(event string, fn HandlerFunc) {
+	r.handlers[event] = fn
+}
+
+// Dispatch passes e to the handler for its event name.
+func (r *Router) Dispatch(ctx context.Context, e *Event) error {
+	if fn, ok := r.handlers[e.Event]; ok {
+		return fn(ctx, e)
+	}
+	if r.Fallback != nil {
+		return r.Fallback(ctx, e)
+	}
+	return nil
+}
+
+func (r *Router) OnTaskCreated(fn func(context.Context, *TaskCreatedEvent) error) {
+	r.Handle(TaskCreated, typed(fn))
+}
+
+func (r *Router) OnTaskUpdated(fn func(context.Context, *TaskUpdatedEvent) error) {
+	r.Handle(TaskUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskDeleted(fn func(context.Context, *TaskDeletedEvent) error) {
+	r.Handle(TaskDeleted, typed(fn))
+}
+
+func (r *Router) OnTaskStatusUpdated(fn func(context.Context, *TaskStatusUpdatedEvent) error) {
+	r.Handle(TaskStatusUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskPriorityUpdated(fn func(context.Context, *TaskPriorityUpdatedEvent) error) {
+	r.Handle(TaskPriorityUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskAssigneeUpdated(fn func(context.Context, *TaskAssigneeUpdatedEvent) error) {
+	r.Handle(TaskAssigneeUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskDueDateUpdated(fn func(context.Context, *TaskDueDateUpdatedEvent) error) {
+	r.Handle(TaskDueDateUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskTagUpdated(fn func(context.Context, *TaskTagUpdatedEvent) error) {
+	r.Handle(TaskTagUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskMoved(fn func(context.Context, *TaskMovedEvent) error) {
+	r.Handle(TaskMoved, typed(fn))
+}
+
+func (r *Router) OnTaskCommentPosted(fn func(context.Context, *TaskCommentEvent) error) {
+	r.Handle(TaskCommentPosted, typed(fn))
+}
+
+func (r *Router) OnTaskCommentUpdated(fn func(context.Context, *TaskCommentEvent) error) {
+	r.Handle(TaskCommentUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskTimeEstimateUpdated(fn func(context.Context, *TaskTimeEstimateUpdatedEvent) error) {
+	r.Handle(TaskTimeEstimateUpdated, typed(fn))
+}
+
+func (r *Router) OnTaskTimeTrackedUpdated(fn func(context.Context, *TaskTimeTrackedUpdatedEvent) error) {
+	r.Handle(TaskTimeTrackedUpdated, typed(fn))
+}
+
+func (r *Router) OnListCreated(fn func(context.Context, *ListEvent) error) {
+	r.Handle(ListCreated, typed(fn))
+}
+
+func (r *Router) OnListUpdated(fn func(context.Context, *ListEvent) error) {
+	r.Handle(ListUpdated, typed(fn))
+}
+
+func (r *Router) OnListDeleted(fn func(context.Context, *ListEvent) error) {
+	r.Handle(ListDeleted, typed(fn))
+}
+
+func (r *Router) OnFolderCreated(fn func(context.Context, *FolderEvent) error) {
+	r.Handle(FolderCreated, typed(fn))
+}
+
+func (r *Router) OnFolderUpdated(fn func(context.Context, *FolderEvent) error) {
+	r.Handle(FolderUpdated, typed(fn))
+}
+
+func (r *Router) OnFolderDeleted(fn func(context.Context, *FolderEvent) error) {
+	r.Handle(FolderDeleted, typed(fn))
+}
+
+func (r *Router) OnSpaceCreated(fn func(context.Context, *SpaceEvent) error) {
+	r.Handle(SpaceCreated, typed(fn))
+}
+
+func (r *Router) OnSpaceUpdated(fn func(context.Context, *SpaceEvent) error) {
+	r.Handle(SpaceUpdated, typed(fn))
+}
+
+func (r *Router) OnSpaceDeleted(fn func(context.Context, *SpaceEvent) error) {
+	r.Handle(SpaceDeleted, typed(fn))
+}
+
+func (r *Router) OnGoalCreated(fn func(context.Context, *GoalEvent) error) {
+	r.Handle(GoalCreated, typed(fn))
+}
+
+func (r *Router) OnGoalUpdated(fn func(context.Context, *GoalEvent) error) {
+	r.Handle(GoalUpdated, typed(fn))
+}
+
+func (r *Router) OnGoalDeleted(fn func(context.Context, *GoalEvent) error) {
+	r.Handle(GoalDeleted, typed(fn))
+}
+
+func (r *Router) OnKeyResultCreated(fn func(context.Context, *KeyResultEvent) error) {
+	r.Handle(KeyResultCreated, typed(fn))
+}
+
+func (r *Router) OnKeyResultUpdated(fn func(context.Context, *KeyResultEvent) error) {
+	r.Handle(KeyResultUpdated, typed(fn))
+}
+
+func (r *Router) OnKeyResultDeleted(fn func(context.Context, *KeyResultEvent) error) {
+	r.Handle(KeyResultDeleted, typed(fn))
+}
+
+// typed adapts a handler of one typed payload to a HandlerFunc.
+func typed[T any](fn func(context.Context, *T) error) HandlerFunc {
+	return func(ctx context.Context, e *Event) error {
+		p, err := Parse(e)
+		if err != nil {
+			return err
+		}
+		t, ok := p.(*T)
+		if !ok {
+			return fmt.Errorf("%s: expected %T, got %T", e.Event, t, p)
+		}
+		return fn(ctx, t)
+	}
+}
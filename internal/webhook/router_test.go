@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	var got []string
+	r := NewRouter()
+	r.OnTaskStatusUpdated(func(ctx context.Context, e *TaskStatusUpdatedEvent) error {
+		got = append(got, "status:"+e.After.Status)
+		return nil
+	})
+	r.OnTaskCommentPosted(func(ctx context.Context, e *TaskCommentEvent) error {
+		got = append(got, "comment:"+e.Comment.TextContent)
+		return nil
+	})
+	r.OnListCreated(func(ctx context.Context, e *ListEvent) error {
+		got = append(got, "list:"+string(e.ListID))
+		return nil
+	})
+	r.OnGoalUpdated(func(ctx context.Context, e *GoalEvent) error {
+		got = append(got, "goal:"+e.User.Username)
+		return nil
+	})
+	r.OnTaskDeleted(func(ctx context.Context, e *TaskDeletedEvent) error {
+		return errors.New("boom")
+	})
+
+	ctx := context.Background()
+	for _, name := range []string{TaskStatusUpdated, TaskCommentPosted, ListCreated, GoalUpdated, TaskMoved} {
+		if err := r.Dispatch(ctx, fixture(t, name)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	if strings.Join(got, " ") != "status:in progress comment:Looks good list:162641543 goal:John" {
+		t.Errorf("got %v", got)
+	}
+	if err := r.Dispatch(ctx, fixture(t, TaskDeleted)); err == nil || err.Error() != "boom" {
+		t.Errorf("expected the handler's error, got %v", err)
+	}
+
+	var fallback []string
+	r.Fallback = func(ctx context.Context, e *Event) error {
+		fallback = append(fallback, e.Event)
+		return nil
+	}
+	if err := r.Dispatch(ctx, fixture(t, TaskMoved)); err != nil || len(fallback) != 1 || fallback[0] != TaskMoved {
+		t.Errorf("expected unhandled events to reach the fallback, got %v, %v", fallback, err)
+	}
+}
+
+func TestRouterHandler(t *testing.T) {
+	var moved *TaskMovedEvent
+	r := NewRouter()
+	r.OnTaskMoved(func(ctx context.Context, e *TaskMovedEvent) error {
+		moved = e
+		return nil
+	})
+	body, err := os.ReadFile(filepath.Join("testdata", "taskMoved.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set(SignatureHeader, Sign("s3cr3t", body))
+	rec := httptest.NewRecorder()
+	Handler("s3cr3t", r.Dispatch).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || moved == nil || moved.After.Name != "Sprint 42" {
+		t.Errorf("expected the delivery to reach the typed handler, got %d and %+v", rec.Code, moved)
+	}
+}
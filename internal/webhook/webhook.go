@@ -0,0 +1,202 @@
+// Package webhook receives ClickUp webhook deliveries: it checks their
+// signature and decodes the events they carry.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a delivery's body, keyed
+// with the webhook's secret.
+const SignatureHeader = "X-Signature"
+
+// MaxBodySize caps the size of a delivery Handler accepts.
+const MaxBodySize = 5 << 20
+
+// Event names ClickUp sends.
+const (
+	TaskCreated             = "taskCreated"
+	TaskUpdated             = "taskUpdated"
+	TaskDeleted             = "taskDeleted"
+	TaskPriorityUpdated     = "taskPriorityUpdated"
+	TaskStatusUpdated       = "taskStatusUpdated"
+	TaskAssigneeUpdated     = "taskAssigneeUpdated"
+	TaskDueDateUpdated      = "taskDueDateUpdated"
+	TaskTagUpdated          = "taskTagUpdated"
+	TaskMoved               = "taskMoved"
+	TaskCommentPosted       = "taskCommentPosted"
+	TaskCommentUpdated      = "taskCommentUpdated"
+	TaskTimeEstimateUpdated = "taskTimeEstimateUpdated"
+	TaskTimeTrackedUpdated  = "taskTimeTrackedUpdated"
+	ListCreated             = "listCreated"
+	ListUpdated             = "listUpdated"
+	ListDeleted             = "listDeleted"
+	FolderCreated           = "folderCreated"
+	FolderUpdated           = "folderUpdated"
+	FolderDeleted           = "folderDeleted"
+	SpaceCreated            = "spaceCreated"
+	SpaceUpdated            = "spaceUpdated"
+	SpaceDeleted            = "spaceDeleted"
+	GoalCreated             = "goalCreated"
+	GoalUpdated             = "goalUpdated"
+	GoalDeleted             = "goalDeleted"
+	KeyResultCreated        = "keyResultCreated"
+	KeyResultUpdated        = "keyResultUpdated"
+	KeyResultDeleted        = "keyResultDeleted"
+)
+
+// Event is a webhook delivery. Which IDs are set depends on the event: task
+// events carry TaskID, list events ListID, and so on. HistoryItems describe
+// what changed.
+type Event struct {
+	Event        string        `json:"event"`
+	WebhookID    string        `json:"webhook_id"`
+	TaskID       ID            `json:"task_id,omitempty"`
+	ListID       ID            `json:"list_id,omitempty"`
+	FolderID     ID            `json:"folder_id,omitempty"`
+	SpaceID      ID            `json:"space_id,omitempty"`
+	GoalID       ID            `json:"goal_id,omitempty"`
+	KeyResultID  ID            `json:"key_result_id,omitempty"`
+	HistoryItems []HistoryItem `json:"history_items,omitempty"`
+}
+
+// HistoryItem is one change in an event. Before and After hold the old and
+// new value, whose shape depends on Field.
+type HistoryItem struct {
+	ID       string          `json:"id"`
+	Type     int             `json:"type"`
+	Date     ID              `json:"date"`
+	Field    string          `json:"field"`
+	ParentID ID              `json:"parent_id,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Source   *string         `json:"source"`
+	User     *User           `json:"user,omitempty"`
+	Before   json.RawMessage `json:"before,omitempty"`
+	After    json.RawMessage `json:"after,omitempty"`
+	Comment  json.RawMessage `json:"comment,omitempty"`
+}
+
+// User is who made a change.
+type User struct {
+	ID             int    `json:"id"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	Color          string `json:"color,omitempty"`
+	Initials       string `json:"initials,omitempty"`
+	ProfilePicture string `json:"profilePicture,omitempty"`
+}
+
+// ID is an identifier or timestamp that ClickUp sends as a string in some
+// payloads and a number in others.
+type ID string
+
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*id = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*id = ID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("expected a string or number, got %s", data)
+	}
+	*id = ID(n.String())
+	return nil
+}
+
+// Int64 returns the ID as a number, such as a date in Unix milliseconds.
+func (id ID) Int64() (int64, error) {
+	return strconv.ParseInt(string(id), 10, 64)
+}
+
+// Sign returns the signature of body for secret, as sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body for secret.
+func Verify(secret string, body []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// Decode parses a delivery body.
+func Decode(body []byte) (*Event, error) {
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return nil, err
+	}
+	if e.Event == "" {
+		return nil, errors.New(`missing "event"`)
+	}
+	return &e, nil
+}
+
+// HandlerFunc processes one verified event.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// Handler returns an http.Handler that accepts POSTed deliveries signed with
+// secret and passes each event to fn. It answers 413 for a body over
+// MaxBodySize, 400 for a body that cannot be read or is not an event, 401
+// for a missing or wrong signature, 500 if fn fails (so ClickUp retries the
+// delivery), and 200 otherwise. fn's errors are logged rather than sent
+// back to the caller.
+func Handler(secret string, fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		case err != nil:
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if !Verify(secret, body, r.Header.Get(SignatureHeader)) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		e, err := Decode(body)
+		if err != nil {
+			http.Error(w, "invalid event: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(r.Context(), e); err != nil {
+			log.Printf("webhook %s: %v", e.Event, err)
+			http.Error(w, "failed to handle event", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const taskStatusBody = `{
+  "event": "taskStatusUpdated",
+  "webhook_id": "7fa3ec74-69a8-4530-a251-8a13730bd204",
+  "task_id": "1vj37mc",
+  "history_items": [{
+    "id": "2800787326392370170",
+    "type": 1,
+    "date": "1642740510345",
+    "field": "status",
+    "parent_id": "162641285",
+    "data": {"status_type": "custom"},
+    "source": null,
+    "user": {"id": 183, "username": "John", "email": "john@company.com", "color": "#7b68ee", "initials": "J"},
+    "before": {"status": "to do", "color": "#f9d900", "type": "open", "orderindex": 0},
+    "after": {"status": "in progress", "color": "#7C4DFF", "type": "custom", "orderindex": 1}
+  }]
+}`
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"event":"taskCreated"}`)
+	sig := Sign("s3cr3t", body)
+	if len(sig) != 64 {
+		t.Fatalf("expected a hex SHA-256, got %q", sig)
+	}
+	if !Verify("s3cr3t", body, sig) || !Verify("s3cr3t", body, strings.ToUpper(sig)) {
+		t.Error("expected the signature to verify")
+	}
+	if Verify("other", body, sig) || Verify("s3cr3t", []byte(`{}`), sig) || Verify("s3cr3t", body, "") || Verify("s3cr3t", body, "zz") {
+		t.Error("expected a wrong secret, body or signature to fail")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	e, err := Decode([]byte(taskStatusBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Event != TaskStatusUpdated || e.TaskID != "1vj37mc" || len(e.HistoryItems) != 1 {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	h := e.HistoryItems[0]
+	if h.Field != "status" || h.ParentID != "162641285" || h.User == nil || h.User.ID != 183 || h.Source != nil {
+		t.Errorf("unexpected history item: %+v", h)
+	}
+	if ms, err := h.Date.Int64(); err != nil || ms != 1642740510345 {
+		t.Errorf("got date %d, %v", ms, err)
+	}
+
+	e, err = Decode([]byte(`{"event":"listCreated","webhook_id":"w1","list_id":162641285}`))
+	if err != nil || e.ListID != "162641285" {
+		t.Errorf("expected a numeric list_id to decode, got %+v, %v", e, err)
+	}
+	if _, err := Decode([]byte(`{"webhook_id":"w1"}`)); err == nil {
+		t.Error("expected a body without an event to fail")
+	}
+	if _, err := Decode([]byte(`{"event":"taskCreated","task_id":true}`)); err == nil {
+		t.Error("expected a boolean ID to fail")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var got []*Event
+	fail := false
+	h := Handler("s3cr3t", func(ctx context.Context, e *Event) error {
+		if fail {
+			return errors.New("downstream failed")
+		}
+		got = append(got, e)
+		return nil
+	})
+	post := func(body, sig string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if sig != "" {
+			req.Header.Set(SignatureHeader, sig)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post(taskStatusBody, Sign("s3cr3t", []byte(taskStatusBody))); code != http.StatusOK {
+		t.Errorf("expected 200, got %d", code)
+	}
+	if len(got) != 1 || got[0].Event != TaskStatusUpdated {
+		t.Fatalf("expected the event to be handled, got %+v", got)
+	}
+	if code := post(taskStatusBody, ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a signature, got %d", code)
+	}
+	if code := post(taskStatusBody, Sign("other", []byte(taskStatusBody))); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a wrong signature, got %d", code)
+	}
+	if code := post(`[]`, Sign("s3cr3t", []byte(`[]`))); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a body that is not an event, got %d", code)
+	}
+	fail = true
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(taskStatusBody))
+	req.Header.Set(SignatureHeader, Sign("s3cr3t", []byte(taskStatusBody)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the handler fails, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "downstream") || !strings.Contains(logged.String(), "downstream failed") {
+		t.Errorf("expected the handler's error to be logged, not sent: body %q, log %q", rec.Body.String(), logged.String())
+	}
+	if len(got) != 1 {
+		t.Errorf("expected rejected deliveries not to be handled, got %d", len(got))
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", rec.Code)
+	}
+
+	big := strings.Repeat(" ", MaxBodySize+1)
+	if code := post(big, Sign("s3cr3t", []byte(big))); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for a body over the limit, got %d", code)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", iotest.ErrReader(errors.New("connection reset"))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a body that fails to read, got %d", rec.Code)
+	}
+}